@@ -0,0 +1,372 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package eth
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ILockProxyABI is the input ABI used to generate the binding from.
+const ILockProxyABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"assetHashMap\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"fromAssetHash\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"toAssetHash\",\"type\":\"bytes\"}],\"name\":\"bindAssetHash\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"targetProxyHash\",\"type\":\"bytes\"}],\"name\":\"bindProxyHash\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"getBalanceFor\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"fromAssetHash\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"lock\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"managerProxyContract\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"proxyHashMap\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"eccmpAddr\",\"type\":\"address\"}],\"name\":\"setManagerProxy\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+// ILockProxyFuncSigs maps the 4-byte function signature to its string representation.
+var ILockProxyFuncSigs = map[string]string{
+	"4f7d9808": "assetHashMap(address,uint64)",
+	"3348f63b": "bindAssetHash(address,uint64,bytes)",
+	"379b98f6": "bindProxyHash(uint64,bytes)",
+	"59c589a1": "getBalanceFor(address)",
+	"84a6d055": "lock(address,uint64,bytes,uint256)",
+	"d798f881": "managerProxyContract()",
+	"9e5767aa": "proxyHashMap(uint64)",
+	"af9980f0": "setManagerProxy(address)",
+}
+
+// ILockProxy is an auto generated Go binding around an Ethereum contract.
+type ILockProxy struct {
+	ILockProxyCaller     // Read-only binding to the contract
+	ILockProxyTransactor // Write-only binding to the contract
+	ILockProxyFilterer   // Log filterer for contract events
+}
+
+// ILockProxyCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ILockProxyCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ILockProxyTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ILockProxyTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ILockProxyFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ILockProxyFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ILockProxySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ILockProxySession struct {
+	Contract     *ILockProxy       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ILockProxyCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ILockProxyCallerSession struct {
+	Contract *ILockProxyCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// ILockProxyTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ILockProxyTransactorSession struct {
+	Contract     *ILockProxyTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// ILockProxyRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ILockProxyRaw struct {
+	Contract *ILockProxy // Generic contract binding to access the raw methods on
+}
+
+// ILockProxyCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ILockProxyCallerRaw struct {
+	Contract *ILockProxyCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ILockProxyTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ILockProxyTransactorRaw struct {
+	Contract *ILockProxyTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewILockProxy creates a new instance of ILockProxy, bound to a specific deployed contract.
+func NewILockProxy(address common.Address, backend bind.ContractBackend) (*ILockProxy, error) {
+	contract, err := bindILockProxy(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ILockProxy{ILockProxyCaller: ILockProxyCaller{contract: contract}, ILockProxyTransactor: ILockProxyTransactor{contract: contract}, ILockProxyFilterer: ILockProxyFilterer{contract: contract}}, nil
+}
+
+// NewILockProxyCaller creates a new read-only instance of ILockProxy, bound to a specific deployed contract.
+func NewILockProxyCaller(address common.Address, caller bind.ContractCaller) (*ILockProxyCaller, error) {
+	contract, err := bindILockProxy(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ILockProxyCaller{contract: contract}, nil
+}
+
+// NewILockProxyTransactor creates a new write-only instance of ILockProxy, bound to a specific deployed contract.
+func NewILockProxyTransactor(address common.Address, transactor bind.ContractTransactor) (*ILockProxyTransactor, error) {
+	contract, err := bindILockProxy(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ILockProxyTransactor{contract: contract}, nil
+}
+
+// NewILockProxyFilterer creates a new log filterer instance of ILockProxy, bound to a specific deployed contract.
+func NewILockProxyFilterer(address common.Address, filterer bind.ContractFilterer) (*ILockProxyFilterer, error) {
+	contract, err := bindILockProxy(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ILockProxyFilterer{contract: contract}, nil
+}
+
+// bindILockProxy binds a generic wrapper to an already deployed contract.
+func bindILockProxy(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ILockProxyABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ILockProxy *ILockProxyRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _ILockProxy.Contract.ILockProxyCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ILockProxy *ILockProxyRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ILockProxy.Contract.ILockProxyTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ILockProxy *ILockProxyRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ILockProxy.Contract.ILockProxyTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ILockProxy *ILockProxyCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _ILockProxy.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ILockProxy *ILockProxyTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ILockProxy.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ILockProxy *ILockProxyTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ILockProxy.Contract.contract.Transact(opts, method, params...)
+}
+
+// AssetHashMap is a free data retrieval call binding the contract method 0x4f7d9808.
+//
+// Solidity: function assetHashMap(address , uint64 ) view returns(bytes)
+func (_ILockProxy *ILockProxyCaller) AssetHashMap(opts *bind.CallOpts, arg0 common.Address, arg1 uint64) ([]byte, error) {
+	var (
+		ret0 = new([]byte)
+	)
+	out := ret0
+	err := _ILockProxy.contract.Call(opts, out, "assetHashMap", arg0, arg1)
+	return *ret0, err
+}
+
+// AssetHashMap is a free data retrieval call binding the contract method 0x4f7d9808.
+//
+// Solidity: function assetHashMap(address , uint64 ) view returns(bytes)
+func (_ILockProxy *ILockProxySession) AssetHashMap(arg0 common.Address, arg1 uint64) ([]byte, error) {
+	return _ILockProxy.Contract.AssetHashMap(&_ILockProxy.CallOpts, arg0, arg1)
+}
+
+// AssetHashMap is a free data retrieval call binding the contract method 0x4f7d9808.
+//
+// Solidity: function assetHashMap(address , uint64 ) view returns(bytes)
+func (_ILockProxy *ILockProxyCallerSession) AssetHashMap(arg0 common.Address, arg1 uint64) ([]byte, error) {
+	return _ILockProxy.Contract.AssetHashMap(&_ILockProxy.CallOpts, arg0, arg1)
+}
+
+// GetBalanceFor is a free data retrieval call binding the contract method 0x59c589a1.
+//
+// Solidity: function getBalanceFor(address ) view returns(uint256)
+func (_ILockProxy *ILockProxyCaller) GetBalanceFor(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _ILockProxy.contract.Call(opts, out, "getBalanceFor", arg0)
+	return *ret0, err
+}
+
+// GetBalanceFor is a free data retrieval call binding the contract method 0x59c589a1.
+//
+// Solidity: function getBalanceFor(address ) view returns(uint256)
+func (_ILockProxy *ILockProxySession) GetBalanceFor(arg0 common.Address) (*big.Int, error) {
+	return _ILockProxy.Contract.GetBalanceFor(&_ILockProxy.CallOpts, arg0)
+}
+
+// GetBalanceFor is a free data retrieval call binding the contract method 0x59c589a1.
+//
+// Solidity: function getBalanceFor(address ) view returns(uint256)
+func (_ILockProxy *ILockProxyCallerSession) GetBalanceFor(arg0 common.Address) (*big.Int, error) {
+	return _ILockProxy.Contract.GetBalanceFor(&_ILockProxy.CallOpts, arg0)
+}
+
+// ManagerProxyContract is a free data retrieval call binding the contract method 0xd798f881.
+//
+// Solidity: function managerProxyContract() view returns(address)
+func (_ILockProxy *ILockProxyCaller) ManagerProxyContract(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _ILockProxy.contract.Call(opts, out, "managerProxyContract")
+	return *ret0, err
+}
+
+// ManagerProxyContract is a free data retrieval call binding the contract method 0xd798f881.
+//
+// Solidity: function managerProxyContract() view returns(address)
+func (_ILockProxy *ILockProxySession) ManagerProxyContract() (common.Address, error) {
+	return _ILockProxy.Contract.ManagerProxyContract(&_ILockProxy.CallOpts)
+}
+
+// ManagerProxyContract is a free data retrieval call binding the contract method 0xd798f881.
+//
+// Solidity: function managerProxyContract() view returns(address)
+func (_ILockProxy *ILockProxyCallerSession) ManagerProxyContract() (common.Address, error) {
+	return _ILockProxy.Contract.ManagerProxyContract(&_ILockProxy.CallOpts)
+}
+
+// ProxyHashMap is a free data retrieval call binding the contract method 0x9e5767aa.
+//
+// Solidity: function proxyHashMap(uint64 ) view returns(bytes)
+func (_ILockProxy *ILockProxyCaller) ProxyHashMap(opts *bind.CallOpts, arg0 uint64) ([]byte, error) {
+	var (
+		ret0 = new([]byte)
+	)
+	out := ret0
+	err := _ILockProxy.contract.Call(opts, out, "proxyHashMap", arg0)
+	return *ret0, err
+}
+
+// ProxyHashMap is a free data retrieval call binding the contract method 0x9e5767aa.
+//
+// Solidity: function proxyHashMap(uint64 ) view returns(bytes)
+func (_ILockProxy *ILockProxySession) ProxyHashMap(arg0 uint64) ([]byte, error) {
+	return _ILockProxy.Contract.ProxyHashMap(&_ILockProxy.CallOpts, arg0)
+}
+
+// ProxyHashMap is a free data retrieval call binding the contract method 0x9e5767aa.
+//
+// Solidity: function proxyHashMap(uint64 ) view returns(bytes)
+func (_ILockProxy *ILockProxyCallerSession) ProxyHashMap(arg0 uint64) ([]byte, error) {
+	return _ILockProxy.Contract.ProxyHashMap(&_ILockProxy.CallOpts, arg0)
+}
+
+// BindAssetHash is a paid mutator transaction binding the contract method 0x3348f63b.
+//
+// Solidity: function bindAssetHash(address fromAssetHash, uint64 toChainId, bytes toAssetHash) returns(bool)
+func (_ILockProxy *ILockProxyTransactor) BindAssetHash(opts *bind.TransactOpts, fromAssetHash common.Address, toChainId uint64, toAssetHash []byte) (*types.Transaction, error) {
+	return _ILockProxy.contract.Transact(opts, "bindAssetHash", fromAssetHash, toChainId, toAssetHash)
+}
+
+// BindAssetHash is a paid mutator transaction binding the contract method 0x3348f63b.
+//
+// Solidity: function bindAssetHash(address fromAssetHash, uint64 toChainId, bytes toAssetHash) returns(bool)
+func (_ILockProxy *ILockProxySession) BindAssetHash(fromAssetHash common.Address, toChainId uint64, toAssetHash []byte) (*types.Transaction, error) {
+	return _ILockProxy.Contract.BindAssetHash(&_ILockProxy.TransactOpts, fromAssetHash, toChainId, toAssetHash)
+}
+
+// BindAssetHash is a paid mutator transaction binding the contract method 0x3348f63b.
+//
+// Solidity: function bindAssetHash(address fromAssetHash, uint64 toChainId, bytes toAssetHash) returns(bool)
+func (_ILockProxy *ILockProxyTransactorSession) BindAssetHash(fromAssetHash common.Address, toChainId uint64, toAssetHash []byte) (*types.Transaction, error) {
+	return _ILockProxy.Contract.BindAssetHash(&_ILockProxy.TransactOpts, fromAssetHash, toChainId, toAssetHash)
+}
+
+// BindProxyHash is a paid mutator transaction binding the contract method 0x379b98f6.
+//
+// Solidity: function bindProxyHash(uint64 toChainId, bytes targetProxyHash) returns(bool)
+func (_ILockProxy *ILockProxyTransactor) BindProxyHash(opts *bind.TransactOpts, toChainId uint64, targetProxyHash []byte) (*types.Transaction, error) {
+	return _ILockProxy.contract.Transact(opts, "bindProxyHash", toChainId, targetProxyHash)
+}
+
+// BindProxyHash is a paid mutator transaction binding the contract method 0x379b98f6.
+//
+// Solidity: function bindProxyHash(uint64 toChainId, bytes targetProxyHash) returns(bool)
+func (_ILockProxy *ILockProxySession) BindProxyHash(toChainId uint64, targetProxyHash []byte) (*types.Transaction, error) {
+	return _ILockProxy.Contract.BindProxyHash(&_ILockProxy.TransactOpts, toChainId, targetProxyHash)
+}
+
+// BindProxyHash is a paid mutator transaction binding the contract method 0x379b98f6.
+//
+// Solidity: function bindProxyHash(uint64 toChainId, bytes targetProxyHash) returns(bool)
+func (_ILockProxy *ILockProxyTransactorSession) BindProxyHash(toChainId uint64, targetProxyHash []byte) (*types.Transaction, error) {
+	return _ILockProxy.Contract.BindProxyHash(&_ILockProxy.TransactOpts, toChainId, targetProxyHash)
+}
+
+// Lock is a paid mutator transaction binding the contract method 0x84a6d055.
+//
+// Solidity: function lock(address fromAssetHash, uint64 toChainId, bytes toAddress, uint256 amount) payable returns(bool)
+func (_ILockProxy *ILockProxyTransactor) Lock(opts *bind.TransactOpts, fromAssetHash common.Address, toChainId uint64, toAddress []byte, amount *big.Int) (*types.Transaction, error) {
+	return _ILockProxy.contract.Transact(opts, "lock", fromAssetHash, toChainId, toAddress, amount)
+}
+
+// Lock is a paid mutator transaction binding the contract method 0x84a6d055.
+//
+// Solidity: function lock(address fromAssetHash, uint64 toChainId, bytes toAddress, uint256 amount) payable returns(bool)
+func (_ILockProxy *ILockProxySession) Lock(fromAssetHash common.Address, toChainId uint64, toAddress []byte, amount *big.Int) (*types.Transaction, error) {
+	return _ILockProxy.Contract.Lock(&_ILockProxy.TransactOpts, fromAssetHash, toChainId, toAddress, amount)
+}
+
+// Lock is a paid mutator transaction binding the contract method 0x84a6d055.
+//
+// Solidity: function lock(address fromAssetHash, uint64 toChainId, bytes toAddress, uint256 amount) payable returns(bool)
+func (_ILockProxy *ILockProxyTransactorSession) Lock(fromAssetHash common.Address, toChainId uint64, toAddress []byte, amount *big.Int) (*types.Transaction, error) {
+	return _ILockProxy.Contract.Lock(&_ILockProxy.TransactOpts, fromAssetHash, toChainId, toAddress, amount)
+}
+
+// SetManagerProxy is a paid mutator transaction binding the contract method 0xaf9980f0.
+//
+// Solidity: function setManagerProxy(address eccmpAddr) returns()
+func (_ILockProxy *ILockProxyTransactor) SetManagerProxy(opts *bind.TransactOpts, eccmpAddr common.Address) (*types.Transaction, error) {
+	return _ILockProxy.contract.Transact(opts, "setManagerProxy", eccmpAddr)
+}
+
+// SetManagerProxy is a paid mutator transaction binding the contract method 0xaf9980f0.
+//
+// Solidity: function setManagerProxy(address eccmpAddr) returns()
+func (_ILockProxy *ILockProxySession) SetManagerProxy(eccmpAddr common.Address) (*types.Transaction, error) {
+	return _ILockProxy.Contract.SetManagerProxy(&_ILockProxy.TransactOpts, eccmpAddr)
+}
+
+// SetManagerProxy is a paid mutator transaction binding the contract method 0xaf9980f0.
+//
+// Solidity: function setManagerProxy(address eccmpAddr) returns()
+func (_ILockProxy *ILockProxyTransactorSession) SetManagerProxy(eccmpAddr common.Address) (*types.Transaction, error) {
+	return _ILockProxy.Contract.SetManagerProxy(&_ILockProxy.TransactOpts, eccmpAddr)
+}
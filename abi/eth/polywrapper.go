@@ -0,0 +1,1246 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package eth
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// PolyWrapperABI is the input ABI used to generate the binding from.
+const PolyWrapperABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_chainId\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Paused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"fromAsset\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"net\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"PolyWrapperLock\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"fromAsset\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"bytes\",\"name\":\"txHash\",\"type\":\"bytes\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"efee\",\"type\":\"uint256\"}],\"name\":\"PolyWrapperSpeedUp\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Unpaused\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"chainId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"extractFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"feeCollector\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"fromAsset\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"lock\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lockProxy\",\"outputs\":[{\"internalType\":\"contractILockProxy\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"collector\",\"type\":\"address\"}],\"name\":\"setFeeCollector\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_lockProxy\",\"type\":\"address\"}],\"name\":\"setLockProxy\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"fromAsset\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"txHash\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"}],\"name\":\"speedUp\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unpause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+// PolyWrapperFuncSigs maps the 4-byte function signature to its string representation.
+var PolyWrapperFuncSigs = map[string]string{
+	"9a8a0592": "chainId()",
+	"1745399d": "extractFee(address)",
+	"c415b95c": "feeCollector()",
+	"60de1a9b": "lock(address,uint64,bytes,uint256,uint256,uint256)",
+	"9d4dc021": "lockProxy()",
+	"8da5cb5b": "owner()",
+	"8456cb59": "pause()",
+	"5c975abb": "paused()",
+	"715018a6": "renounceOwnership()",
+	"a42dce80": "setFeeCollector(address)",
+	"6f2b6ee6": "setLockProxy(address)",
+	"d3ed7c76": "speedUp(address,bytes,uint256)",
+	"f2fde38b": "transferOwnership(address)",
+	"3f4ba83a": "unpause()",
+}
+
+// PolyWrapper is an auto generated Go binding around an Ethereum contract.
+type PolyWrapper struct {
+	PolyWrapperCaller     // Read-only binding to the contract
+	PolyWrapperTransactor // Write-only binding to the contract
+	PolyWrapperFilterer   // Log filterer for contract events
+}
+
+// PolyWrapperCaller is an auto generated read-only Go binding around an Ethereum contract.
+type PolyWrapperCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolyWrapperTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type PolyWrapperTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolyWrapperFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PolyWrapperFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolyWrapperSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PolyWrapperSession struct {
+	Contract     *PolyWrapper      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// PolyWrapperCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PolyWrapperCallerSession struct {
+	Contract *PolyWrapperCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// PolyWrapperTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PolyWrapperTransactorSession struct {
+	Contract     *PolyWrapperTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// PolyWrapperRaw is an auto generated low-level Go binding around an Ethereum contract.
+type PolyWrapperRaw struct {
+	Contract *PolyWrapper // Generic contract binding to access the raw methods on
+}
+
+// PolyWrapperCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PolyWrapperCallerRaw struct {
+	Contract *PolyWrapperCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// PolyWrapperTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PolyWrapperTransactorRaw struct {
+	Contract *PolyWrapperTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPolyWrapper creates a new instance of PolyWrapper, bound to a specific deployed contract.
+func NewPolyWrapper(address common.Address, backend bind.ContractBackend) (*PolyWrapper, error) {
+	contract, err := bindPolyWrapper(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapper{PolyWrapperCaller: PolyWrapperCaller{contract: contract}, PolyWrapperTransactor: PolyWrapperTransactor{contract: contract}, PolyWrapperFilterer: PolyWrapperFilterer{contract: contract}}, nil
+}
+
+// NewPolyWrapperCaller creates a new read-only instance of PolyWrapper, bound to a specific deployed contract.
+func NewPolyWrapperCaller(address common.Address, caller bind.ContractCaller) (*PolyWrapperCaller, error) {
+	contract, err := bindPolyWrapper(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperCaller{contract: contract}, nil
+}
+
+// NewPolyWrapperTransactor creates a new write-only instance of PolyWrapper, bound to a specific deployed contract.
+func NewPolyWrapperTransactor(address common.Address, transactor bind.ContractTransactor) (*PolyWrapperTransactor, error) {
+	contract, err := bindPolyWrapper(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperTransactor{contract: contract}, nil
+}
+
+// NewPolyWrapperFilterer creates a new log filterer instance of PolyWrapper, bound to a specific deployed contract.
+func NewPolyWrapperFilterer(address common.Address, filterer bind.ContractFilterer) (*PolyWrapperFilterer, error) {
+	contract, err := bindPolyWrapper(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperFilterer{contract: contract}, nil
+}
+
+// bindPolyWrapper binds a generic wrapper to an already deployed contract.
+func bindPolyWrapper(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(PolyWrapperABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolyWrapper *PolyWrapperRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _PolyWrapper.Contract.PolyWrapperCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolyWrapper *PolyWrapperRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.PolyWrapperTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolyWrapper *PolyWrapperRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.PolyWrapperTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolyWrapper *PolyWrapperCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+	return _PolyWrapper.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolyWrapper *PolyWrapperTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolyWrapper *PolyWrapperTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.contract.Transact(opts, method, params...)
+}
+
+// ChainId is a free data retrieval call binding the contract method 0x9a8a0592.
+//
+// Solidity: function chainId() view returns(uint256)
+func (_PolyWrapper *PolyWrapperCaller) ChainId(opts *bind.CallOpts) (*big.Int, error) {
+	var (
+		ret0 = new(*big.Int)
+	)
+	out := ret0
+	err := _PolyWrapper.contract.Call(opts, out, "chainId")
+	return *ret0, err
+}
+
+// ChainId is a free data retrieval call binding the contract method 0x9a8a0592.
+//
+// Solidity: function chainId() view returns(uint256)
+func (_PolyWrapper *PolyWrapperSession) ChainId() (*big.Int, error) {
+	return _PolyWrapper.Contract.ChainId(&_PolyWrapper.CallOpts)
+}
+
+// ChainId is a free data retrieval call binding the contract method 0x9a8a0592.
+//
+// Solidity: function chainId() view returns(uint256)
+func (_PolyWrapper *PolyWrapperCallerSession) ChainId() (*big.Int, error) {
+	return _PolyWrapper.Contract.ChainId(&_PolyWrapper.CallOpts)
+}
+
+// FeeCollector is a free data retrieval call binding the contract method 0xc415b95c.
+//
+// Solidity: function feeCollector() view returns(address)
+func (_PolyWrapper *PolyWrapperCaller) FeeCollector(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _PolyWrapper.contract.Call(opts, out, "feeCollector")
+	return *ret0, err
+}
+
+// FeeCollector is a free data retrieval call binding the contract method 0xc415b95c.
+//
+// Solidity: function feeCollector() view returns(address)
+func (_PolyWrapper *PolyWrapperSession) FeeCollector() (common.Address, error) {
+	return _PolyWrapper.Contract.FeeCollector(&_PolyWrapper.CallOpts)
+}
+
+// FeeCollector is a free data retrieval call binding the contract method 0xc415b95c.
+//
+// Solidity: function feeCollector() view returns(address)
+func (_PolyWrapper *PolyWrapperCallerSession) FeeCollector() (common.Address, error) {
+	return _PolyWrapper.Contract.FeeCollector(&_PolyWrapper.CallOpts)
+}
+
+// LockProxy is a free data retrieval call binding the contract method 0x9d4dc021.
+//
+// Solidity: function lockProxy() view returns(address)
+func (_PolyWrapper *PolyWrapperCaller) LockProxy(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _PolyWrapper.contract.Call(opts, out, "lockProxy")
+	return *ret0, err
+}
+
+// LockProxy is a free data retrieval call binding the contract method 0x9d4dc021.
+//
+// Solidity: function lockProxy() view returns(address)
+func (_PolyWrapper *PolyWrapperSession) LockProxy() (common.Address, error) {
+	return _PolyWrapper.Contract.LockProxy(&_PolyWrapper.CallOpts)
+}
+
+// LockProxy is a free data retrieval call binding the contract method 0x9d4dc021.
+//
+// Solidity: function lockProxy() view returns(address)
+func (_PolyWrapper *PolyWrapperCallerSession) LockProxy() (common.Address, error) {
+	return _PolyWrapper.Contract.LockProxy(&_PolyWrapper.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PolyWrapper *PolyWrapperCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _PolyWrapper.contract.Call(opts, out, "owner")
+	return *ret0, err
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PolyWrapper *PolyWrapperSession) Owner() (common.Address, error) {
+	return _PolyWrapper.Contract.Owner(&_PolyWrapper.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PolyWrapper *PolyWrapperCallerSession) Owner() (common.Address, error) {
+	return _PolyWrapper.Contract.Owner(&_PolyWrapper.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_PolyWrapper *PolyWrapperCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var (
+		ret0 = new(bool)
+	)
+	out := ret0
+	err := _PolyWrapper.contract.Call(opts, out, "paused")
+	return *ret0, err
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_PolyWrapper *PolyWrapperSession) Paused() (bool, error) {
+	return _PolyWrapper.Contract.Paused(&_PolyWrapper.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_PolyWrapper *PolyWrapperCallerSession) Paused() (bool, error) {
+	return _PolyWrapper.Contract.Paused(&_PolyWrapper.CallOpts)
+}
+
+// ExtractFee is a paid mutator transaction binding the contract method 0x1745399d.
+//
+// Solidity: function extractFee(address token) returns()
+func (_PolyWrapper *PolyWrapperTransactor) ExtractFee(opts *bind.TransactOpts, token common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "extractFee", token)
+}
+
+// ExtractFee is a paid mutator transaction binding the contract method 0x1745399d.
+//
+// Solidity: function extractFee(address token) returns()
+func (_PolyWrapper *PolyWrapperSession) ExtractFee(token common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.ExtractFee(&_PolyWrapper.TransactOpts, token)
+}
+
+// ExtractFee is a paid mutator transaction binding the contract method 0x1745399d.
+//
+// Solidity: function extractFee(address token) returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) ExtractFee(token common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.ExtractFee(&_PolyWrapper.TransactOpts, token)
+}
+
+// Lock is a paid mutator transaction binding the contract method 0x60de1a9b.
+//
+// Solidity: function lock(address fromAsset, uint64 toChainId, bytes toAddress, uint256 amount, uint256 fee, uint256 id) payable returns()
+func (_PolyWrapper *PolyWrapperTransactor) Lock(opts *bind.TransactOpts, fromAsset common.Address, toChainId uint64, toAddress []byte, amount *big.Int, fee *big.Int, id *big.Int) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "lock", fromAsset, toChainId, toAddress, amount, fee, id)
+}
+
+// Lock is a paid mutator transaction binding the contract method 0x60de1a9b.
+//
+// Solidity: function lock(address fromAsset, uint64 toChainId, bytes toAddress, uint256 amount, uint256 fee, uint256 id) payable returns()
+func (_PolyWrapper *PolyWrapperSession) Lock(fromAsset common.Address, toChainId uint64, toAddress []byte, amount *big.Int, fee *big.Int, id *big.Int) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.Lock(&_PolyWrapper.TransactOpts, fromAsset, toChainId, toAddress, amount, fee, id)
+}
+
+// Lock is a paid mutator transaction binding the contract method 0x60de1a9b.
+//
+// Solidity: function lock(address fromAsset, uint64 toChainId, bytes toAddress, uint256 amount, uint256 fee, uint256 id) payable returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) Lock(fromAsset common.Address, toChainId uint64, toAddress []byte, amount *big.Int, fee *big.Int, id *big.Int) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.Lock(&_PolyWrapper.TransactOpts, fromAsset, toChainId, toAddress, amount, fee, id)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_PolyWrapper *PolyWrapperTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_PolyWrapper *PolyWrapperSession) Pause() (*types.Transaction, error) {
+	return _PolyWrapper.Contract.Pause(&_PolyWrapper.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) Pause() (*types.Transaction, error) {
+	return _PolyWrapper.Contract.Pause(&_PolyWrapper.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_PolyWrapper *PolyWrapperTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_PolyWrapper *PolyWrapperSession) RenounceOwnership() (*types.Transaction, error) {
+	return _PolyWrapper.Contract.RenounceOwnership(&_PolyWrapper.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _PolyWrapper.Contract.RenounceOwnership(&_PolyWrapper.TransactOpts)
+}
+
+// SetFeeCollector is a paid mutator transaction binding the contract method 0xa42dce80.
+//
+// Solidity: function setFeeCollector(address collector) returns()
+func (_PolyWrapper *PolyWrapperTransactor) SetFeeCollector(opts *bind.TransactOpts, collector common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "setFeeCollector", collector)
+}
+
+// SetFeeCollector is a paid mutator transaction binding the contract method 0xa42dce80.
+//
+// Solidity: function setFeeCollector(address collector) returns()
+func (_PolyWrapper *PolyWrapperSession) SetFeeCollector(collector common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.SetFeeCollector(&_PolyWrapper.TransactOpts, collector)
+}
+
+// SetFeeCollector is a paid mutator transaction binding the contract method 0xa42dce80.
+//
+// Solidity: function setFeeCollector(address collector) returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) SetFeeCollector(collector common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.SetFeeCollector(&_PolyWrapper.TransactOpts, collector)
+}
+
+// SetLockProxy is a paid mutator transaction binding the contract method 0x6f2b6ee6.
+//
+// Solidity: function setLockProxy(address _lockProxy) returns()
+func (_PolyWrapper *PolyWrapperTransactor) SetLockProxy(opts *bind.TransactOpts, _lockProxy common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "setLockProxy", _lockProxy)
+}
+
+// SetLockProxy is a paid mutator transaction binding the contract method 0x6f2b6ee6.
+//
+// Solidity: function setLockProxy(address _lockProxy) returns()
+func (_PolyWrapper *PolyWrapperSession) SetLockProxy(_lockProxy common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.SetLockProxy(&_PolyWrapper.TransactOpts, _lockProxy)
+}
+
+// SetLockProxy is a paid mutator transaction binding the contract method 0x6f2b6ee6.
+//
+// Solidity: function setLockProxy(address _lockProxy) returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) SetLockProxy(_lockProxy common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.SetLockProxy(&_PolyWrapper.TransactOpts, _lockProxy)
+}
+
+// SpeedUp is a paid mutator transaction binding the contract method 0xd3ed7c76.
+//
+// Solidity: function speedUp(address fromAsset, bytes txHash, uint256 fee) payable returns()
+func (_PolyWrapper *PolyWrapperTransactor) SpeedUp(opts *bind.TransactOpts, fromAsset common.Address, txHash []byte, fee *big.Int) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "speedUp", fromAsset, txHash, fee)
+}
+
+// SpeedUp is a paid mutator transaction binding the contract method 0xd3ed7c76.
+//
+// Solidity: function speedUp(address fromAsset, bytes txHash, uint256 fee) payable returns()
+func (_PolyWrapper *PolyWrapperSession) SpeedUp(fromAsset common.Address, txHash []byte, fee *big.Int) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.SpeedUp(&_PolyWrapper.TransactOpts, fromAsset, txHash, fee)
+}
+
+// SpeedUp is a paid mutator transaction binding the contract method 0xd3ed7c76.
+//
+// Solidity: function speedUp(address fromAsset, bytes txHash, uint256 fee) payable returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) SpeedUp(fromAsset common.Address, txHash []byte, fee *big.Int) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.SpeedUp(&_PolyWrapper.TransactOpts, fromAsset, txHash, fee)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_PolyWrapper *PolyWrapperTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_PolyWrapper *PolyWrapperSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.TransferOwnership(&_PolyWrapper.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _PolyWrapper.Contract.TransferOwnership(&_PolyWrapper.TransactOpts, newOwner)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_PolyWrapper *PolyWrapperTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolyWrapper.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_PolyWrapper *PolyWrapperSession) Unpause() (*types.Transaction, error) {
+	return _PolyWrapper.Contract.Unpause(&_PolyWrapper.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_PolyWrapper *PolyWrapperTransactorSession) Unpause() (*types.Transaction, error) {
+	return _PolyWrapper.Contract.Unpause(&_PolyWrapper.TransactOpts)
+}
+
+// PolyWrapperOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the PolyWrapper contract.
+type PolyWrapperOwnershipTransferredIterator struct {
+	Event *PolyWrapperOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolyWrapperOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolyWrapperOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolyWrapperOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolyWrapperOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolyWrapperOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolyWrapperOwnershipTransferred represents a OwnershipTransferred event raised by the PolyWrapper contract.
+type PolyWrapperOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PolyWrapper *PolyWrapperFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*PolyWrapperOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _PolyWrapper.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperOwnershipTransferredIterator{contract: _PolyWrapper.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PolyWrapper *PolyWrapperFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *PolyWrapperOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _PolyWrapper.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolyWrapperOwnershipTransferred)
+				if err := _PolyWrapper.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PolyWrapper *PolyWrapperFilterer) ParseOwnershipTransferred(log types.Log) (*PolyWrapperOwnershipTransferred, error) {
+	event := new(PolyWrapperOwnershipTransferred)
+	if err := _PolyWrapper.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// PolyWrapperPausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the PolyWrapper contract.
+type PolyWrapperPausedIterator struct {
+	Event *PolyWrapperPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolyWrapperPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolyWrapperPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolyWrapperPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolyWrapperPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolyWrapperPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolyWrapperPaused represents a Paused event raised by the PolyWrapper contract.
+type PolyWrapperPaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_PolyWrapper *PolyWrapperFilterer) FilterPaused(opts *bind.FilterOpts) (*PolyWrapperPausedIterator, error) {
+
+	logs, sub, err := _PolyWrapper.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperPausedIterator{contract: _PolyWrapper.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_PolyWrapper *PolyWrapperFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *PolyWrapperPaused) (event.Subscription, error) {
+
+	logs, sub, err := _PolyWrapper.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolyWrapperPaused)
+				if err := _PolyWrapper.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_PolyWrapper *PolyWrapperFilterer) ParsePaused(log types.Log) (*PolyWrapperPaused, error) {
+	event := new(PolyWrapperPaused)
+	if err := _PolyWrapper.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// PolyWrapperPolyWrapperLockIterator is returned from FilterPolyWrapperLock and is used to iterate over the raw logs and unpacked data for PolyWrapperLock events raised by the PolyWrapper contract.
+type PolyWrapperPolyWrapperLockIterator struct {
+	Event *PolyWrapperPolyWrapperLock // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolyWrapperPolyWrapperLockIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolyWrapperPolyWrapperLock)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolyWrapperPolyWrapperLock)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolyWrapperPolyWrapperLockIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolyWrapperPolyWrapperLockIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolyWrapperPolyWrapperLock represents a PolyWrapperLock event raised by the PolyWrapper contract.
+type PolyWrapperPolyWrapperLock struct {
+	FromAsset common.Address
+	Sender    common.Address
+	ToChainId uint64
+	ToAddress []byte
+	Net       *big.Int
+	Fee       *big.Int
+	Id        *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterPolyWrapperLock is a free log retrieval operation binding the contract event 0x2b0591052cc6602e870d3994f0a1b173fdac98c215cb3b0baf84eaca5a0aa81e.
+//
+// Solidity: event PolyWrapperLock(address indexed fromAsset, address indexed sender, uint64 toChainId, bytes toAddress, uint256 net, uint256 fee, uint256 id)
+func (_PolyWrapper *PolyWrapperFilterer) FilterPolyWrapperLock(opts *bind.FilterOpts, fromAsset []common.Address, sender []common.Address) (*PolyWrapperPolyWrapperLockIterator, error) {
+
+	var fromAssetRule []interface{}
+	for _, fromAssetItem := range fromAsset {
+		fromAssetRule = append(fromAssetRule, fromAssetItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _PolyWrapper.contract.FilterLogs(opts, "PolyWrapperLock", fromAssetRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperPolyWrapperLockIterator{contract: _PolyWrapper.contract, event: "PolyWrapperLock", logs: logs, sub: sub}, nil
+}
+
+// WatchPolyWrapperLock is a free log subscription operation binding the contract event 0x2b0591052cc6602e870d3994f0a1b173fdac98c215cb3b0baf84eaca5a0aa81e.
+//
+// Solidity: event PolyWrapperLock(address indexed fromAsset, address indexed sender, uint64 toChainId, bytes toAddress, uint256 net, uint256 fee, uint256 id)
+func (_PolyWrapper *PolyWrapperFilterer) WatchPolyWrapperLock(opts *bind.WatchOpts, sink chan<- *PolyWrapperPolyWrapperLock, fromAsset []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var fromAssetRule []interface{}
+	for _, fromAssetItem := range fromAsset {
+		fromAssetRule = append(fromAssetRule, fromAssetItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _PolyWrapper.contract.WatchLogs(opts, "PolyWrapperLock", fromAssetRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolyWrapperPolyWrapperLock)
+				if err := _PolyWrapper.contract.UnpackLog(event, "PolyWrapperLock", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePolyWrapperLock is a log parse operation binding the contract event 0x2b0591052cc6602e870d3994f0a1b173fdac98c215cb3b0baf84eaca5a0aa81e.
+//
+// Solidity: event PolyWrapperLock(address indexed fromAsset, address indexed sender, uint64 toChainId, bytes toAddress, uint256 net, uint256 fee, uint256 id)
+func (_PolyWrapper *PolyWrapperFilterer) ParsePolyWrapperLock(log types.Log) (*PolyWrapperPolyWrapperLock, error) {
+	event := new(PolyWrapperPolyWrapperLock)
+	if err := _PolyWrapper.contract.UnpackLog(event, "PolyWrapperLock", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// PolyWrapperPolyWrapperSpeedUpIterator is returned from FilterPolyWrapperSpeedUp and is used to iterate over the raw logs and unpacked data for PolyWrapperSpeedUp events raised by the PolyWrapper contract.
+type PolyWrapperPolyWrapperSpeedUpIterator struct {
+	Event *PolyWrapperPolyWrapperSpeedUp // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolyWrapperPolyWrapperSpeedUpIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolyWrapperPolyWrapperSpeedUp)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolyWrapperPolyWrapperSpeedUp)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolyWrapperPolyWrapperSpeedUpIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolyWrapperPolyWrapperSpeedUpIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolyWrapperPolyWrapperSpeedUp represents a PolyWrapperSpeedUp event raised by the PolyWrapper contract.
+type PolyWrapperPolyWrapperSpeedUp struct {
+	FromAsset common.Address
+	TxHash    common.Hash
+	Sender    common.Address
+	Efee      *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterPolyWrapperSpeedUp is a free log retrieval operation binding the contract event 0xf6579aef3e0d086d986c5d6972659f8a0d8602ef7945b054be1b88e088773ef6.
+//
+// Solidity: event PolyWrapperSpeedUp(address indexed fromAsset, bytes indexed txHash, address indexed sender, uint256 efee)
+func (_PolyWrapper *PolyWrapperFilterer) FilterPolyWrapperSpeedUp(opts *bind.FilterOpts, fromAsset []common.Address, txHash [][]byte, sender []common.Address) (*PolyWrapperPolyWrapperSpeedUpIterator, error) {
+
+	var fromAssetRule []interface{}
+	for _, fromAssetItem := range fromAsset {
+		fromAssetRule = append(fromAssetRule, fromAssetItem)
+	}
+	var txHashRule []interface{}
+	for _, txHashItem := range txHash {
+		txHashRule = append(txHashRule, txHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _PolyWrapper.contract.FilterLogs(opts, "PolyWrapperSpeedUp", fromAssetRule, txHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperPolyWrapperSpeedUpIterator{contract: _PolyWrapper.contract, event: "PolyWrapperSpeedUp", logs: logs, sub: sub}, nil
+}
+
+// WatchPolyWrapperSpeedUp is a free log subscription operation binding the contract event 0xf6579aef3e0d086d986c5d6972659f8a0d8602ef7945b054be1b88e088773ef6.
+//
+// Solidity: event PolyWrapperSpeedUp(address indexed fromAsset, bytes indexed txHash, address indexed sender, uint256 efee)
+func (_PolyWrapper *PolyWrapperFilterer) WatchPolyWrapperSpeedUp(opts *bind.WatchOpts, sink chan<- *PolyWrapperPolyWrapperSpeedUp, fromAsset []common.Address, txHash [][]byte, sender []common.Address) (event.Subscription, error) {
+
+	var fromAssetRule []interface{}
+	for _, fromAssetItem := range fromAsset {
+		fromAssetRule = append(fromAssetRule, fromAssetItem)
+	}
+	var txHashRule []interface{}
+	for _, txHashItem := range txHash {
+		txHashRule = append(txHashRule, txHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _PolyWrapper.contract.WatchLogs(opts, "PolyWrapperSpeedUp", fromAssetRule, txHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolyWrapperPolyWrapperSpeedUp)
+				if err := _PolyWrapper.contract.UnpackLog(event, "PolyWrapperSpeedUp", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePolyWrapperSpeedUp is a log parse operation binding the contract event 0xf6579aef3e0d086d986c5d6972659f8a0d8602ef7945b054be1b88e088773ef6.
+//
+// Solidity: event PolyWrapperSpeedUp(address indexed fromAsset, bytes indexed txHash, address indexed sender, uint256 efee)
+func (_PolyWrapper *PolyWrapperFilterer) ParsePolyWrapperSpeedUp(log types.Log) (*PolyWrapperPolyWrapperSpeedUp, error) {
+	event := new(PolyWrapperPolyWrapperSpeedUp)
+	if err := _PolyWrapper.contract.UnpackLog(event, "PolyWrapperSpeedUp", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// PolyWrapperUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the PolyWrapper contract.
+type PolyWrapperUnpausedIterator struct {
+	Event *PolyWrapperUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolyWrapperUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolyWrapperUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolyWrapperUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolyWrapperUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolyWrapperUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolyWrapperUnpaused represents a Unpaused event raised by the PolyWrapper contract.
+type PolyWrapperUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_PolyWrapper *PolyWrapperFilterer) FilterUnpaused(opts *bind.FilterOpts) (*PolyWrapperUnpausedIterator, error) {
+
+	logs, sub, err := _PolyWrapper.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &PolyWrapperUnpausedIterator{contract: _PolyWrapper.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_PolyWrapper *PolyWrapperFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *PolyWrapperUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _PolyWrapper.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolyWrapperUnpaused)
+				if err := _PolyWrapper.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_PolyWrapper *PolyWrapperFilterer) ParseUnpaused(log types.Log) (*PolyWrapperUnpaused, error) {
+	event := new(PolyWrapperUnpaused)
+	if err := _PolyWrapper.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,54 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PolyWrapperArtifact is the truffle build of PolyWrapper.sol, from `npm run compile` in src/eth
+const PolyWrapperArtifact = "src/eth/build/contracts/PolyWrapper.json"
+
+// ReadPolyWrapperBin reads the creation code of PolyWrapper.sol from a truffle artifact, see
+// PolyWrapperArtifact. truffle-config.js builds it with solc 0.6.12 and 200 optimizer runs
+func ReadPolyWrapperBin(artifact string) ([]byte, error) {
+	raw, err := ioutil.ReadFile(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("ReadPolyWrapperBin, read %s err: %v", artifact, err)
+	}
+	build := &struct {
+		ContractName string `json:"contractName"`
+		Bytecode     string `json:"bytecode"`
+	}{}
+	if err = json.Unmarshal(raw, build); err != nil {
+		return nil, fmt.Errorf("ReadPolyWrapperBin, unmarshal %s err: %v", artifact, err)
+	}
+	code := common.FromHex(build.Bytecode)
+	if build.ContractName != "PolyWrapper" || len(code) == 0 {
+		return nil, fmt.Errorf("ReadPolyWrapperBin, %s is not a build of PolyWrapper", artifact)
+	}
+	return code, nil
+}
+
+// DeployPolyWrapper deploys a new Ethereum contract of code, see ReadPolyWrapperBin, binding an instance of PolyWrapper to it.
+func DeployPolyWrapper(auth *bind.TransactOpts, backend bind.ContractBackend, code []byte, _owner common.Address, _chainId *big.Int) (common.Address, *types.Transaction, *PolyWrapper, error) {
+	if len(code) == 0 {
+		return common.Address{}, nil, nil, fmt.Errorf("DeployPolyWrapper, no code of PolyWrapper")
+	}
+	parsed, err := abi.JSON(strings.NewReader(PolyWrapperABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	address, tx, contract, err := bind.DeployContract(auth, parsed, code, backend, _owner, _chainId)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &PolyWrapper{PolyWrapperCaller: PolyWrapperCaller{contract: contract}, PolyWrapperTransactor: PolyWrapperTransactor{contract: contract}, PolyWrapperFilterer: PolyWrapperFilterer{contract: contract}}, nil
+}
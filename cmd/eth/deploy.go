@@ -0,0 +1,227 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	abieth "github.com/skyinglyh1/poly_wrapper/abi/eth"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"io/ioutil"
+	"math/big"
+	"os"
+)
+
+// EthDeployment is the entry of a network in src/eth/deployments/deployed.json
+type EthDeployment struct {
+	Owner       string `json:"owner,omitempty"`
+	PolyWrapper string `json:"polywrapper,omitempty"`
+	LockProxy   string `json:"lockproxy"`
+	// TxHashs are the deploy, setLockProxy and setFeeCollector txs of DeployWrapper
+	TxHashs []string `json:"-"`
+}
+
+// DeployWrapper deploys code, the creation code of the poly wrapper, owned by the signer for
+// polyChainId, the poly chain id of this chain, then sets lockProxy and feeCollector like
+// 2_deploy_polywrapper.js. The signer collects the fees if feeCollector is empty. The deployment is returned with the error of any step after the deploy tx is sent
+func (this *EthInvoker) DeployWrapper(code []byte, polyChainId uint64, lockProxy common.Address, feeCollector common.Address) (*EthDeployment, error) {
+	res, wrapper, err := this.deployWrapper(code, polyChainId, lockProxy)
+	if err != nil {
+		return res, fmt.Errorf("[DeployWrapper], %v", err)
+	}
+	if err = this.setupWrapper(res, wrapper, polyChainId, lockProxy, feeCollector); err != nil {
+		return res, fmt.Errorf("[DeployWrapper], %v", err)
+	}
+	return res, nil
+}
+
+// deployWrapper sends the deploy tx of the poly wrapper and waits for it to be mined
+func (this *EthInvoker) deployWrapper(code []byte, polyChainId uint64, lockProxy common.Address) (*EthDeployment, *abieth.PolyWrapper, error) {
+	if polyChainId == 0 {
+		return nil, nil, fmt.Errorf("the poly chain id must not be 0")
+	}
+	proxyCode, err := this.Cli.CodeAt(context.Background(), lockProxy, nil)
+	if err != nil || len(proxyCode) == 0 {
+		return nil, nil, fmt.Errorf("no lock proxy at %s, err: %v", lockProxy.Hex(), err)
+	}
+	owner := this.Signer.EthAddress()
+	addr, tx, wrapper, err := abieth.DeployPolyWrapper(this.transactOpts(), this.Cli, code, owner, new(big.Int).SetUint64(polyChainId))
+	if err != nil {
+		return nil, nil, fmt.Errorf("deploy err: %v", err)
+	}
+	log.Infof("Eth deploy poly wrapper %s, txHash: %s", addr.Hex(), tx.Hash().Hex())
+	res := &EthDeployment{Owner: owner.Hex(), PolyWrapper: addr.Hex(), LockProxy: lockProxy.Hex(), TxHashs: []string{tx.Hash().Hex()}}
+	if _, err = this.waitTx(tx); err != nil {
+		return res, nil, fmt.Errorf("deploy waitTx err: %v", err)
+	}
+	return res, wrapper, nil
+}
+
+// setupWrapper sets the lock proxy and fee collector of a mined poly wrapper and checks its state
+func (this *EthInvoker) setupWrapper(res *EthDeployment, wrapper *abieth.PolyWrapper, polyChainId uint64, lockProxy common.Address, feeCollector common.Address) error {
+	owner := this.Signer.EthAddress()
+	if feeCollector == (common.Address{}) {
+		feeCollector = owner
+	}
+	tx, err := wrapper.SetLockProxy(this.transactOpts(), lockProxy)
+	if err != nil {
+		return fmt.Errorf("setLockProxy err: %v", err)
+	}
+	log.Infof("Eth poly wrapper setLockProxy %s, txHash: %s", lockProxy.Hex(), tx.Hash().Hex())
+	res.TxHashs = append(res.TxHashs, tx.Hash().Hex())
+	if _, err = this.waitTx(tx); err != nil {
+		return fmt.Errorf("setLockProxy waitTx err: %v", err)
+	}
+	if tx, err = wrapper.SetFeeCollector(this.transactOpts(), feeCollector); err != nil {
+		return fmt.Errorf("setFeeCollector err: %v", err)
+	}
+	log.Infof("Eth poly wrapper setFeeCollector %s, txHash: %s", feeCollector.Hex(), tx.Hash().Hex())
+	res.TxHashs = append(res.TxHashs, tx.Hash().Hex())
+	if _, err = this.waitTx(tx); err != nil {
+		return fmt.Errorf("setFeeCollector waitTx err: %v", err)
+	}
+
+	opts := &bind.CallOpts{}
+	if got, err := wrapper.Owner(opts); err != nil || got != owner {
+		return fmt.Errorf("owner is %s, not %s, err: %v", got.Hex(), owner.Hex(), err)
+	}
+	if got, err := wrapper.ChainId(opts); err != nil || got.Cmp(new(big.Int).SetUint64(polyChainId)) != 0 {
+		return fmt.Errorf("chainId is %v, not %d, err: %v", got, polyChainId, err)
+	}
+	if got, err := wrapper.LockProxy(opts); err != nil || got != lockProxy {
+		return fmt.Errorf("lockProxy is %s, not %s, err: %v", got.Hex(), lockProxy.Hex(), err)
+	}
+	if got, err := wrapper.FeeCollector(opts); err != nil || got != feeCollector {
+		return fmt.Errorf("feeCollector is %s, not %s, err: %v", got.Hex(), feeCollector.Hex(), err)
+	}
+	return nil
+}
+
+// DeployWrapperFromConfig loads the bytecode of EthWrapperArtifact and deploys the poly wrapper
+// for EthPolyChainId with the lock proxy of EthNetwork in EthDeployments, like
+// 2_deploy_polywrapper.js. The entry of EthNetwork in EthDeploymentsOut is written once the
+// wrapper is mined, before its lock proxy and fee collector are set, so a failed setter leaves
+// the address of the wrapper there
+func (this *EthInvoker) DeployWrapperFromConfig(cfg *config.TestConfig) (*EthDeployment, error) {
+	if cfg.EthDeployments == "" || cfg.EthDeploymentsOut == "" || cfg.EthNetwork == "" {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], ethDeployments, ethDeploymentsOut and ethNetwork are all needed")
+	}
+	artifact := cfg.EthWrapperArtifact
+	if artifact == "" {
+		artifact = abieth.PolyWrapperArtifact
+	}
+	code, err := abieth.ReadPolyWrapperBin(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], %v", err)
+	}
+	deployments, err := ReadDeployments(cfg.EthDeployments)
+	if err != nil {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], %v", err)
+	}
+	deployed := deployments[cfg.EthNetwork]
+	if deployed == nil || !common.IsHexAddress(deployed.LockProxy) {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], no lockproxy of %s in %s", cfg.EthNetwork, cfg.EthDeployments)
+	}
+	var collector common.Address
+	if cfg.EthFeeCollector != "" {
+		if !common.IsHexAddress(cfg.EthFeeCollector) {
+			return nil, fmt.Errorf("[DeployWrapperFromConfig], invalid ethFeeCollector %s", cfg.EthFeeCollector)
+		}
+		collector = common.HexToAddress(cfg.EthFeeCollector)
+	}
+	lockProxy := common.HexToAddress(deployed.LockProxy)
+	res, wrapper, err := this.deployWrapper(code, cfg.EthPolyChainId, lockProxy)
+	if err != nil {
+		return res, fmt.Errorf("[DeployWrapperFromConfig], %v", err)
+	}
+	if err = WriteDeployment(cfg.EthDeploymentsOut, cfg.EthNetwork, res); err != nil {
+		return res, fmt.Errorf("[DeployWrapperFromConfig], %v", err)
+	}
+	if err = this.setupWrapper(res, wrapper, cfg.EthPolyChainId, lockProxy, collector); err != nil {
+		return res, fmt.Errorf("[DeployWrapperFromConfig], %v", err)
+	}
+	return res, nil
+}
+
+// ReadDeployments reads a deployed.json, keyed by the truffle network name
+func ReadDeployments(path string) (map[string]*EthDeployment, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s err: %v", path, err)
+	}
+	res := make(map[string]*EthDeployment)
+	if err = json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("unmarshal %s err: %v", path, err)
+	}
+	return res, nil
+}
+
+// WriteDeployment sets the entry of network in the deployments file at path, which is created if
+// missing. The other networks keep their entries and their order and a new network is appended,
+// indented by 4 spaces like writeContractAddresses of utils.js
+func WriteDeployment(path string, network string, deployment *EthDeployment) error {
+	names := make([]string, 0)
+	entries := make(map[string]json.RawMessage)
+	raw, err := ioutil.ReadFile(path)
+	if err == nil {
+		if names, entries, err = readEntries(raw); err != nil {
+			return fmt.Errorf("read %s err: %v", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("read %s err: %v", path, err)
+	}
+	if _, ok := entries[network]; !ok {
+		names = append(names, network)
+	}
+	if entries[network], err = json.Marshal(deployment); err != nil {
+		return fmt.Errorf("marshal deployment err: %v", err)
+	}
+	buf := bytes.NewBufferString("{")
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(name)
+		buf.WriteString("\n    " + string(key) + ": ")
+		if err = json.Indent(buf, entries[name], "    ", "    "); err != nil {
+			return fmt.Errorf("entry %s of %s err: %v", name, path, err)
+		}
+	}
+	if len(names) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}")
+	if err = ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write %s err: %v", path, err)
+	}
+	return nil
+}
+
+// readEntries returns the keys of a JSON object in the order they appear, and their values
+func readEntries(raw []byte) ([]string, map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, nil, fmt.Errorf("not a JSON object")
+	}
+	names := make([]string, 0)
+	entries := make(map[string]json.RawMessage)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		name := t.(string)
+		value := json.RawMessage{}
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("entry %s: %v", name, err)
+		}
+		if _, ok := entries[name]; !ok {
+			names = append(names, name)
+		}
+		entries[name] = value
+	}
+	return names, entries, nil
+}
@@ -0,0 +1,113 @@
+package eth
+
+import (
+	"bytes"
+	"github.com/ethereum/go-ethereum/common"
+	abieth "github.com/skyinglyh1/poly_wrapper/abi/eth"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_DeployEth_Wrapper(t *testing.T) {
+	fake := newFakeEthRpc(t)
+	invoker := fake.invoker()
+	lockProxy := common.HexToAddress("0xD8aE73e06552E270340b63A8bcAbf9277a1aac99")
+	fake.Contracts[lockProxy] = newFakeLockProxy(invoker.Signer.EthAddress()).contract(t)
+	bin := []byte{0x60, 0x80, 0x60, 0x40}
+	wrapperAbi := parseAbi(t, abieth.PolyWrapperABI)
+	var deployed *fakePolyWrapper
+	foreignOwner := false
+	fake.Create = func(from common.Address, data []byte) fakeContract {
+		if !bytes.HasPrefix(data, bin) {
+			return nil
+		}
+		args, err := wrapperAbi.Constructor.Inputs.UnpackValues(data[len(bin):])
+		if err != nil || args[1].(*big.Int).Sign() == 0 {
+			return nil
+		}
+		deployed = &fakePolyWrapper{node: fake, owner: args[0].(common.Address), chainId: args[1].(*big.Int).Uint64()}
+		if foreignOwner {
+			deployed.owner = common.HexToAddress("0x02")
+		}
+		return deployed.contract(t)
+	}
+
+	dir := t.TempDir()
+	artifact := filepath.Join(dir, "PolyWrapper.json")
+	ioutil.WriteFile(artifact, []byte(`{"contractName": "PolyWrapper", "bytecode": "0x60806040"}`), 0644)
+	deployments := filepath.Join(dir, "deployed.json")
+	input := []byte(`{
+    "ropsten": {
+        "lockproxy": "0xD8aE73e06552E270340b63A8bcAbf9277a1aac99"
+    },
+    "bsc": {
+        "lockproxy": "0x2f7ac9436ba4B548f9582af91CA1Ef02cd2F1f03"
+    }
+}`)
+	ioutil.WriteFile(deployments, input, 0644)
+	out := filepath.Join(dir, "development.json")
+	ioutil.WriteFile(out, []byte(`{"rinkeby": {"polywrapper": "0x01"}, "bsc": {"polywrapper": "0x02"}}`), 0644)
+	cfg := &config.TestConfig{EthWrapperArtifact: artifact, EthDeployments: deployments, EthDeploymentsOut: out, EthNetwork: "ropsten", EthPolyChainId: 2}
+
+	res, err := invoker.DeployWrapperFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := invoker.Signer.EthAddress()
+	if len(fake.Sent) != 3 || len(res.TxHashs) != 3 || fake.Sent[0].To() != nil {
+		t.Fatalf("expect deploy, setLockProxy and setFeeCollector, got %d txs", len(fake.Sent))
+	}
+	if deployed.owner != owner || deployed.chainId != 2 || deployed.lockProxy != lockProxy || deployed.feeCollector != owner {
+		t.Fatalf("unexpected wrapper: %+v", deployed)
+	}
+	written, err := ReadDeployments(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := written["ropsten"]
+	if entry.Owner != owner.Hex() || entry.LockProxy != lockProxy.Hex() || !common.IsHexAddress(entry.PolyWrapper) || fake.Contracts[common.HexToAddress(entry.PolyWrapper)] == nil {
+		t.Fatalf("unexpected deployment entry: %+v", entry)
+	}
+	// deployed.json is only read, the existing networks of the output keep their order
+	if raw, _ := ioutil.ReadFile(deployments); !bytes.Equal(raw, input) {
+		t.Fatalf("deployed.json changed:\n%s", raw)
+	}
+	raw, _ := ioutil.ReadFile(out)
+	rinkeby, bsc, ropsten := strings.Index(string(raw), `"rinkeby"`), strings.Index(string(raw), `"bsc"`), strings.Index(string(raw), `"ropsten"`)
+	if written["rinkeby"].PolyWrapper != "0x01" || rinkeby < 0 || rinkeby > bsc || bsc > ropsten ||
+		!strings.Contains(string(raw), "\n        \"polywrapper\": \"") {
+		t.Fatalf("unexpected development.json:\n%s", raw)
+	}
+
+	// a network without lock proxy and a lock proxy without code are rejected
+	cfg.EthNetwork = "heco"
+	if _, err = invoker.DeployWrapperFromConfig(cfg); err == nil || len(fake.Sent) != 3 {
+		t.Fatalf("expect an unknown network to fail, got %v", err)
+	}
+	if _, err = invoker.DeployWrapper(bin, 2, common.HexToAddress("0x01"), common.Address{}); err == nil || len(fake.Sent) != 3 {
+		t.Fatalf("expect a lock proxy without code to fail, got %v", err)
+	}
+
+	// the entry is written once the wrapper is mined, even if a setter fails after
+	bscLockProxy := common.HexToAddress("0x2f7ac9436ba4B548f9582af91CA1Ef02cd2F1f03")
+	fake.Contracts[bscLockProxy] = newFakeLockProxy(owner).contract(t)
+	cfg.EthNetwork = "bsc"
+	foreignOwner = true
+	res, err = invoker.DeployWrapperFromConfig(cfg)
+	if err == nil || res == nil || len(fake.Sent) != 4 {
+		t.Fatalf("expect setLockProxy of a wrapper not owned by the signer to fail, got %v", err)
+	}
+	if written, err = ReadDeployments(out); err != nil {
+		t.Fatal(err)
+	}
+	if entry = written["bsc"]; entry.PolyWrapper != res.PolyWrapper || entry.LockProxy != bscLockProxy.Hex() || written["ropsten"].PolyWrapper == res.PolyWrapper {
+		t.Fatalf("unexpected deployment entry after a failed setter: %+v", entry)
+	}
+	if raw, _ = ioutil.ReadFile(out); strings.Index(string(raw), `"bsc"`) != bsc {
+		t.Fatalf("expect bsc to be replaced in place:\n%s", raw)
+	}
+}
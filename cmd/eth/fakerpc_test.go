@@ -0,0 +1,231 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeContract runs a call of the contract it is registered for. write is false for eth_call
+// and eth_estimateGas, whose changes must be dropped, an error reverts the call
+type fakeContract func(from common.Address, value *big.Int, data []byte, write bool) ([]byte, []*types.Log, error)
+
+// fakeEthRpc is an in-process Ethereum node. Calls and txs run the fakeContract of their
+// address, a sent tx is mined at once in the next block, reverted if its contract fails
+type fakeEthRpc struct {
+	t       *testing.T
+	srv     *httptest.Server
+	mu      sync.Mutex
+	ChainId *big.Int
+	// Contracts are the contracts deployed, eth_getCode is empty for other addresses
+	Contracts map[common.Address]fakeContract
+	// Create makes the contract of a creation tx, whose data is the creation code and the packed
+	// constructor arguments, the creation reverts if it returns nil
+	Create func(from common.Address, data []byte) fakeContract
+	// Balances are the ether balances, a sent tx moves its value to the contract
+	Balances map[common.Address]*big.Int
+	Height   uint64
+	// Sent are the txs received with their sender and receipt
+	Sent     []*types.Transaction
+	From     []common.Address
+	Receipts map[common.Hash]*types.Receipt
+}
+
+func newFakeEthRpc(t *testing.T) *fakeEthRpc {
+	f := &fakeEthRpc{
+		t:         t,
+		ChainId:   big.NewInt(42),
+		Contracts: map[common.Address]fakeContract{},
+		Balances:  map[common.Address]*big.Int{},
+		Height:    100,
+		Receipts:  map[common.Hash]*types.Receipt{},
+	}
+	f.srv = httptest.NewServer(f)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+// invoker dials the fake with a new key, which holds 1 ether
+func (this *fakeEthRpc) invoker() *EthInvoker {
+	key, _ := crypto.GenerateKey()
+	s := &signer.EthKeySigner{Key: key}
+	this.Balances[s.EthAddress()] = big.NewInt(1e18)
+	invoker, err := NewEthInvoker(this.srv.URL, s)
+	if err != nil {
+		this.t.Fatal(err)
+	}
+	invoker.ConfirmTimeout = 10 * time.Second
+	return invoker
+}
+
+type fakeCallArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Value *hexutil.Big    `json:"value"`
+	Data  hexutil.Bytes   `json:"data"`
+}
+
+func (this *fakeEthRpc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &struct {
+		Id     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	this.mu.Lock()
+	result, err := this.handle(req.Method, req.Params)
+	this.mu.Unlock()
+	res := map[string]interface{}{"jsonrpc": "2.0", "id": req.Id}
+	if err != nil {
+		res["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	} else {
+		res["result"] = result
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+func (this *fakeEthRpc) handle(method string, params []json.RawMessage) (interface{}, error) {
+	switch method {
+	case "eth_chainId":
+		return (*hexutil.Big)(this.ChainId), nil
+	case "eth_blockNumber":
+		return hexutil.Uint64(this.Height), nil
+	case "eth_gasPrice":
+		return (*hexutil.Big)(big.NewInt(1e9)), nil
+	case "eth_getCode":
+		var addr common.Address
+		json.Unmarshal(params[0], &addr)
+		if this.Contracts[addr] == nil {
+			return hexutil.Bytes{}, nil
+		}
+		return hexutil.Bytes{0x60}, nil
+	case "eth_getBalance":
+		var addr common.Address
+		json.Unmarshal(params[0], &addr)
+		return (*hexutil.Big)(this.balanceOf(addr)), nil
+	case "eth_getTransactionCount":
+		var addr common.Address
+		json.Unmarshal(params[0], &addr)
+		nonce := uint64(0)
+		for _, from := range this.From {
+			if from == addr {
+				nonce++
+			}
+		}
+		return hexutil.Uint64(nonce), nil
+	case "eth_call", "eth_estimateGas":
+		args := &fakeCallArgs{}
+		if err := json.Unmarshal(params[0], args); err != nil {
+			return nil, err
+		}
+		out, _, err := this.run(args.From, args.To, args.Value.ToInt(), args.Data, false)
+		if err != nil {
+			return nil, fmt.Errorf("execution reverted: %v", err)
+		}
+		if method == "eth_estimateGas" {
+			return hexutil.Uint64(100000), nil
+		}
+		return hexutil.Bytes(out), nil
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		json.Unmarshal(params[0], &raw)
+		tx := &types.Transaction{}
+		if err := rlp.DecodeBytes(raw, tx); err != nil {
+			return nil, err
+		}
+		from, err := types.Sender(types.NewEIP155Signer(this.ChainId), tx)
+		if err != nil {
+			return nil, err
+		}
+		this.Height++
+		this.Sent = append(this.Sent, tx)
+		this.From = append(this.From, from)
+		receipt := &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			TxHash:            tx.Hash(),
+			GasUsed:           100000,
+			CumulativeGasUsed: 100000,
+			BlockNumber:       new(big.Int).SetUint64(this.Height),
+			Logs:              []*types.Log{},
+		}
+		var logs []*types.Log
+		if tx.To() == nil {
+			err = this.create(from, tx, receipt)
+		} else {
+			_, logs, err = this.run(from, tx.To(), tx.Value(), tx.Data(), true)
+		}
+		if err != nil {
+			receipt.Status = types.ReceiptStatusFailed
+		} else {
+			for i, l := range logs {
+				l.TxHash, l.BlockNumber, l.Index = tx.Hash(), this.Height, uint(i)
+			}
+			receipt.Logs = append(receipt.Logs, logs...)
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		this.Receipts[tx.Hash()] = receipt
+		return tx.Hash(), nil
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		json.Unmarshal(params[0], &hash)
+		if receipt, ok := this.Receipts[hash]; ok {
+			return receipt, nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("the method %s does not exist", method)
+}
+
+func (this *fakeEthRpc) create(from common.Address, tx *types.Transaction, receipt *types.Receipt) error {
+	var contract fakeContract
+	if this.Create != nil {
+		contract = this.Create(from, tx.Data())
+	}
+	if contract == nil {
+		return fmt.Errorf("creation reverted")
+	}
+	receipt.ContractAddress = crypto.CreateAddress(from, tx.Nonce())
+	this.Contracts[receipt.ContractAddress] = contract
+	return nil
+}
+
+// run calls the contract at to, a write moves value to it if the call succeeds
+func (this *fakeEthRpc) run(from common.Address, to *common.Address, value *big.Int, data []byte, write bool) ([]byte, []*types.Log, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+	if this.balanceOf(from).Cmp(value) < 0 {
+		return nil, nil, fmt.Errorf("insufficient funds")
+	}
+	if to == nil || this.Contracts[*to] == nil {
+		return nil, nil, nil
+	}
+	out, logs, err := this.Contracts[*to](from, value, data, write)
+	if err != nil || !write {
+		return out, logs, err
+	}
+	this.Balances[from] = new(big.Int).Sub(this.balanceOf(from), value)
+	this.Balances[*to] = new(big.Int).Add(this.balanceOf(*to), value)
+	return out, logs, nil
+}
+
+func (this *fakeEthRpc) balanceOf(addr common.Address) *big.Int {
+	if b, ok := this.Balances[addr]; ok {
+		return b
+	}
+	return new(big.Int)
+}
@@ -0,0 +1,83 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"math/big"
+	"time"
+)
+
+// write methods wait at most DefaultConfirmTimeout for their tx to be mined
+const DefaultConfirmTimeout = 5 * time.Minute
+
+type EthInvoker struct {
+	Cli     *ethclient.Client
+	ChainId *big.Int
+	Signer  signer.EthSigner
+	// write methods wait at most ConfirmTimeout for their tx to be mined
+	ConfirmTimeout time.Duration
+}
+
+// NewEthInvoker dials url and reads the chain id the txs signed by s are bound to
+func NewEthInvoker(url string, s signer.EthSigner) (*EthInvoker, error) {
+	cli, err := ethclient.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("NewEthInvoker, dial %s err: %v", url, err)
+	}
+	chainId, err := cli.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("NewEthInvoker, ChainID err: %v", err)
+	}
+	return &EthInvoker{Cli: cli, ChainId: chainId, Signer: s, ConfirmTimeout: DefaultConfirmTimeout}, nil
+}
+
+// NewEthInvokerFromConfig signs with EthPrivateKey if set, otherwise with EthAddress of the
+// remote EthSignerUrl
+func NewEthInvokerFromConfig(cfg *config.TestConfig) (*EthInvoker, error) {
+	var s signer.EthSigner
+	switch {
+	case cfg.EthPrivateKey != "":
+		key, err := signer.NewEthKeySigner(cfg.EthPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("NewEthInvokerFromConfig, %v", err)
+		}
+		s = key
+	case cfg.EthSignerUrl != "":
+		if !common.IsHexAddress(cfg.EthAddress) {
+			return nil, fmt.Errorf("NewEthInvokerFromConfig, invalid ethAddress %s for the remote signer", cfg.EthAddress)
+		}
+		s = signer.NewRemoteEthSigner(cfg.EthSignerUrl, common.HexToAddress(cfg.EthAddress))
+	default:
+		return nil, fmt.Errorf("NewEthInvokerFromConfig, neither ethPrivateKey nor ethSignerUrl is set")
+	}
+	return NewEthInvoker(cfg.EthUrl, s)
+}
+
+// transactOpts lets the bindings send txs signed by Signer
+func (this *EthInvoker) transactOpts() *bind.TransactOpts {
+	return signer.NewEthTransactOpts(this.Signer, this.ChainId)
+}
+
+// waitTx waits for tx to be mined, a reverted tx is an error
+func (this *EthInvoker) waitTx(tx *types.Transaction) (*types.Receipt, error) {
+	timeout := this.ConfirmTimeout
+	if timeout == 0 {
+		timeout = DefaultConfirmTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(ctx, this.Cli, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx %s not mined in %v: %v", tx.Hash().Hex(), timeout, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("tx %s reverted in block %v", tx.Hash().Hex(), receipt.BlockNumber)
+	}
+	return receipt, nil
+}
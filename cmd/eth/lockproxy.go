@@ -0,0 +1,73 @@
+package eth
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	abieth "github.com/skyinglyh1/poly_wrapper/abi/eth"
+	"github.com/skyinglyh1/poly_wrapper/log"
+)
+
+// GetProxyHash returns the lock proxy bound for toChainId as stored, empty if unbound
+func (this *EthInvoker) GetProxyHash(ethLockProxy common.Address, toChainId uint64) ([]byte, error) {
+	proxy, err := abieth.NewILockProxyCaller(ethLockProxy, this.Cli)
+	if err != nil {
+		return nil, fmt.Errorf("[GetProxyHash], NewILockProxyCaller err: %v", err)
+	}
+	res, err := proxy.ProxyHashMap(&bind.CallOpts{}, toChainId)
+	if err != nil {
+		return nil, fmt.Errorf("[GetProxyHash], proxyHashMap err: %v", err)
+	}
+	return res, nil
+}
+
+// GetAssetHashs returns the asset hashes on toChainId bound for fromAssetHashs as stored, empty if unbound
+func (this *EthInvoker) GetAssetHashs(ethLockProxy common.Address, toChainId uint64, fromAssetHashs []common.Address) ([][]byte, error) {
+	proxy, err := abieth.NewILockProxyCaller(ethLockProxy, this.Cli)
+	if err != nil {
+		return nil, fmt.Errorf("[GetAssetHashs], NewILockProxyCaller err: %v", err)
+	}
+	res := make([][]byte, len(fromAssetHashs))
+	for i, from := range fromAssetHashs {
+		if res[i], err = proxy.AssetHashMap(&bind.CallOpts{}, from, toChainId); err != nil {
+			return nil, fmt.Errorf("[GetAssetHashs], assetHashMap of %s err: %v", from.Hex(), err)
+		}
+	}
+	return res, nil
+}
+
+// BindProxyHash binds toProxyHash as the lock proxy of toChainId and waits for the tx, the
+// signer must be the owner of the lock proxy
+func (this *EthInvoker) BindProxyHash(ethLockProxy common.Address, toChainId uint64, toProxyHash []byte) (string, error) {
+	proxy, err := abieth.NewILockProxyTransactor(ethLockProxy, this.Cli)
+	if err != nil {
+		return "", fmt.Errorf("[BindProxyHash], NewILockProxyTransactor err: %v", err)
+	}
+	tx, err := proxy.BindProxyHash(this.transactOpts(), toChainId, toProxyHash)
+	if err != nil {
+		return "", fmt.Errorf("[BindProxyHash], send tx err: %v", err)
+	}
+	log.Infof("Eth bindProxyHash, txHash: %s", tx.Hash().Hex())
+	if _, err = this.waitTx(tx); err != nil {
+		return tx.Hash().Hex(), fmt.Errorf("[BindProxyHash], waitTx err: %v", err)
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// BindAssetHash binds fromAssetHash to toAssetHash on toChainId and waits for the tx, the
+// signer must be the owner of the lock proxy
+func (this *EthInvoker) BindAssetHash(ethLockProxy common.Address, fromAssetHash common.Address, toChainId uint64, toAssetHash []byte) (string, error) {
+	proxy, err := abieth.NewILockProxyTransactor(ethLockProxy, this.Cli)
+	if err != nil {
+		return "", fmt.Errorf("[BindAssetHash], NewILockProxyTransactor err: %v", err)
+	}
+	tx, err := proxy.BindAssetHash(this.transactOpts(), fromAssetHash, toChainId, toAssetHash)
+	if err != nil {
+		return "", fmt.Errorf("[BindAssetHash], send tx err: %v", err)
+	}
+	log.Infof("Eth bindAssetHash, txHash: %s", tx.Hash().Hex())
+	if _, err = this.waitTx(tx); err != nil {
+		return tx.Hash().Hex(), fmt.Errorf("[BindAssetHash], waitTx err: %v", err)
+	}
+	return tx.Hash().Hex(), nil
+}
@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	abieth "github.com/skyinglyh1/poly_wrapper/abi/eth"
+	"math/big"
+	"testing"
+)
+
+// fakeLockProxy keeps the bindings of a lock proxy, only owner may bind
+type fakeLockProxy struct {
+	owner  common.Address
+	proxys map[uint64][]byte
+	assets map[string][]byte
+}
+
+func newFakeLockProxy(owner common.Address) *fakeLockProxy {
+	return &fakeLockProxy{owner: owner, proxys: map[uint64][]byte{}, assets: map[string][]byte{}}
+}
+
+func (this *fakeLockProxy) contract(t *testing.T) fakeContract {
+	proxyAbi := parseAbi(t, abieth.ILockProxyABI)
+	return func(from common.Address, value *big.Int, data []byte, write bool) ([]byte, []*types.Log, error) {
+		method, args := unpackCall(proxyAbi, data)
+		if method == nil {
+			return nil, nil, fmt.Errorf("unknown method")
+		}
+		switch method.Name {
+		case "proxyHashMap":
+			out, err := method.Outputs.Pack(this.proxys[args[0].(uint64)])
+			return out, nil, err
+		case "assetHashMap":
+			out, err := method.Outputs.Pack(this.assets[fmt.Sprintf("%s:%d", args[0].(common.Address).Hex(), args[1].(uint64))])
+			return out, nil, err
+		case "bindProxyHash", "bindAssetHash":
+			if from != this.owner {
+				return nil, nil, fmt.Errorf("msg.sender is not owner")
+			}
+			if !write {
+				return nil, nil, nil
+			}
+			if method.Name == "bindProxyHash" {
+				this.proxys[args[0].(uint64)] = args[1].([]byte)
+			} else {
+				this.assets[fmt.Sprintf("%s:%d", args[0].(common.Address).Hex(), args[1].(uint64))] = args[2].([]byte)
+			}
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("unexpected method %s", method.Name)
+	}
+}
+
+func Test_EthLockProxyBindings(t *testing.T) {
+	fake := newFakeEthRpc(t)
+	invoker := fake.invoker()
+	lockProxy := common.HexToAddress("0x250e76987d838a75310c34bf422ea9f1ac4cc906")
+	proxy := newFakeLockProxy(invoker.Signer.EthAddress())
+	fake.Contracts[lockProxy] = proxy.contract(t)
+	usdt := common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7")
+	eth := common.Address{}
+	neoProxy := common.FromHex("0x7f25d672e8626d2beaa26f2cb40da6b91f40a382")
+	neoUsdt := common.FromHex("0x282bf0b5fe5a48e47f2f9b12e1ec4b7d2327dc06")
+
+	if invoker.ChainId.Cmp(fake.ChainId) != 0 {
+		t.Fatalf("unexpected chain id %v", invoker.ChainId)
+	}
+	if res, err := invoker.GetProxyHash(lockProxy, 4); err != nil || len(res) != 0 {
+		t.Fatalf("expect an unbound proxy, got %x, err: %v", res, err)
+	}
+	if _, err := invoker.BindProxyHash(lockProxy, 4, neoProxy); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := invoker.BindAssetHash(lockProxy, usdt, 4, neoUsdt); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Sent) != 2 || fake.From[1] != invoker.Signer.EthAddress() || fake.Sent[1].Nonce() != 1 {
+		t.Fatalf("unexpected txs: %d", len(fake.Sent))
+	}
+	res, err := invoker.GetProxyHash(lockProxy, 4)
+	if err != nil || !bytes.Equal(res, neoProxy) {
+		t.Fatalf("unexpected proxy %x, err: %v", res, err)
+	}
+	assets, err := invoker.GetAssetHashs(lockProxy, 4, []common.Address{usdt, eth})
+	if err != nil || len(assets) != 2 || !bytes.Equal(assets[0], neoUsdt) || len(assets[1]) != 0 {
+		t.Fatalf("unexpected assets %x, err: %v", assets, err)
+	}
+
+	// only the owner binds, a reverted estimate sends nothing
+	proxy.owner = eth
+	if _, err = invoker.BindProxyHash(lockProxy, 5, neoProxy); err == nil || len(fake.Sent) != 2 {
+		t.Fatalf("expect the bind of another owner to fail, got %v, %d txs", err, len(fake.Sent))
+	}
+	if _, err = invoker.GetProxyHash(common.HexToAddress("0x01"), 4); err == nil {
+		t.Fatal("a read of an address without code should fail")
+	}
+}
@@ -0,0 +1,176 @@
+package eth
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	abieth "github.com/skyinglyh1/poly_wrapper/abi/eth"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"math/big"
+	"strings"
+)
+
+// EthAsset is the fromAsset of ether, which the poly wrapper takes as msg.value
+var EthAsset = common.Address{}
+
+// Lock locks amount of fromAsset, fee included, through the poly wrapper and returns its
+// PolyWrapperLock event. Ether is sent as msg.value, which must equal amount, an ERC20 is
+// approved to the wrapper first if the allowance is short
+func (this *EthInvoker) Lock(ethPolyWrapper common.Address, fromAsset common.Address, toChainId uint64, toAddress []byte, amount, fee, id *big.Int) (*abieth.IPolyWrapperPolyWrapperLock, error) {
+	if amount.Cmp(fee) <= 0 {
+		return nil, fmt.Errorf("[Lock], amount %v must be more than fee %v", amount, fee)
+	}
+	wrapper, err := abieth.NewIPolyWrapper(ethPolyWrapper, this.Cli)
+	if err != nil {
+		return nil, fmt.Errorf("[Lock], NewIPolyWrapper err: %v", err)
+	}
+	lockId, err := polyWrapperEventId("PolyWrapperLock")
+	if err != nil {
+		return nil, fmt.Errorf("[Lock], %v", err)
+	}
+	opts, err := this.pullOpts(ethPolyWrapper, fromAsset, amount)
+	if err != nil {
+		return nil, fmt.Errorf("[Lock], %v", err)
+	}
+	tx, err := wrapper.Lock(opts, fromAsset, toChainId, toAddress, amount, fee, id)
+	if err != nil {
+		return nil, fmt.Errorf("[Lock], send tx err: %v", err)
+	}
+	log.Infof("Eth poly wrapper lock, txHash: %s", tx.Hash().Hex())
+	receipt, err := this.waitTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("[Lock], waitTx err: %v", err)
+	}
+	for _, l := range receipt.Logs {
+		if l.Address != ethPolyWrapper || len(l.Topics) == 0 || l.Topics[0] != lockId {
+			continue
+		}
+		event, err := wrapper.ParsePolyWrapperLock(*l)
+		if err != nil {
+			return nil, fmt.Errorf("[Lock], ParsePolyWrapperLock of tx %s err: %v", tx.Hash().Hex(), err)
+		}
+		return event, nil
+	}
+	return nil, fmt.Errorf("[Lock], no PolyWrapperLock event in tx %s", tx.Hash().Hex())
+}
+
+// SpeedUp pays fee of fromAsset for the relay of the lock tx txHash and returns its
+// PolyWrapperSpeedUp event. The fee is taken like the amount of Lock
+func (this *EthInvoker) SpeedUp(ethPolyWrapper common.Address, fromAsset common.Address, txHash []byte, fee *big.Int) (*abieth.IPolyWrapperPolyWrapperSpeedUp, error) {
+	wrapper, err := abieth.NewIPolyWrapper(ethPolyWrapper, this.Cli)
+	if err != nil {
+		return nil, fmt.Errorf("[SpeedUp], NewIPolyWrapper err: %v", err)
+	}
+	speedUpId, err := polyWrapperEventId("PolyWrapperSpeedUp")
+	if err != nil {
+		return nil, fmt.Errorf("[SpeedUp], %v", err)
+	}
+	opts, err := this.pullOpts(ethPolyWrapper, fromAsset, fee)
+	if err != nil {
+		return nil, fmt.Errorf("[SpeedUp], %v", err)
+	}
+	tx, err := wrapper.SpeedUp(opts, fromAsset, txHash, fee)
+	if err != nil {
+		return nil, fmt.Errorf("[SpeedUp], send tx err: %v", err)
+	}
+	log.Infof("Eth poly wrapper speedUp, txHash: %s", tx.Hash().Hex())
+	receipt, err := this.waitTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("[SpeedUp], waitTx err: %v", err)
+	}
+	for _, l := range receipt.Logs {
+		if l.Address != ethPolyWrapper || len(l.Topics) == 0 || l.Topics[0] != speedUpId {
+			continue
+		}
+		event, err := wrapper.ParsePolyWrapperSpeedUp(*l)
+		if err != nil {
+			return nil, fmt.Errorf("[SpeedUp], ParsePolyWrapperSpeedUp of tx %s err: %v", tx.Hash().Hex(), err)
+		}
+		return event, nil
+	}
+	return nil, fmt.Errorf("[SpeedUp], no PolyWrapperSpeedUp event in tx %s", tx.Hash().Hex())
+}
+
+// ExtractFee moves the fees of token held by the poly wrapper to the fee collector, which
+// must be the signer
+func (this *EthInvoker) ExtractFee(ethPolyWrapper common.Address, token common.Address) (string, error) {
+	wrapper, err := abieth.NewIPolyWrapper(ethPolyWrapper, this.Cli)
+	if err != nil {
+		return "", fmt.Errorf("[ExtractFee], NewIPolyWrapper err: %v", err)
+	}
+	collector, err := wrapper.FeeCollector(&bind.CallOpts{})
+	if err != nil {
+		return "", fmt.Errorf("[ExtractFee], feeCollector err: %v", err)
+	}
+	if collector != this.Signer.EthAddress() {
+		return "", fmt.Errorf("[ExtractFee], signer %s is not the fee collector %s", this.Signer.EthAddress().Hex(), collector.Hex())
+	}
+	tx, err := wrapper.ExtractFee(this.transactOpts(), token)
+	if err != nil {
+		return "", fmt.Errorf("[ExtractFee], send tx err: %v", err)
+	}
+	log.Infof("Eth poly wrapper extractFee, txHash: %s", tx.Hash().Hex())
+	if _, err = this.waitTx(tx); err != nil {
+		return tx.Hash().Hex(), fmt.Errorf("[ExtractFee], waitTx err: %v", err)
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// pullOpts are the transact options of a call in which the poly wrapper pulls amount of
+// fromAsset from the signer, see _pull of PolyWrapper.sol
+func (this *EthInvoker) pullOpts(ethPolyWrapper common.Address, fromAsset common.Address, amount *big.Int) (*bind.TransactOpts, error) {
+	opts := this.transactOpts()
+	if fromAsset == EthAsset {
+		opts.Value = amount
+		return opts, nil
+	}
+	if err := this.ensureAllowance(fromAsset, ethPolyWrapper, amount); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// ensureAllowance approves amount of token to spender if the allowance of the signer is
+// short. A nonzero allowance is reset to 0 first, as tokens like USDT require
+func (this *EthInvoker) ensureAllowance(token common.Address, spender common.Address, amount *big.Int) error {
+	erc20, err := abieth.NewIERC20(token, this.Cli)
+	if err != nil {
+		return fmt.Errorf("NewIERC20 err: %v", err)
+	}
+	allowance, err := erc20.Allowance(&bind.CallOpts{}, this.Signer.EthAddress(), spender)
+	if err != nil {
+		return fmt.Errorf("allowance of %s err: %v", token.Hex(), err)
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+	approves := []*big.Int{amount}
+	if allowance.Sign() != 0 {
+		approves = []*big.Int{new(big.Int), amount}
+	}
+	for _, value := range approves {
+		tx, err := erc20.Approve(this.transactOpts(), spender, value)
+		if err != nil {
+			return fmt.Errorf("approve %v of %s err: %v", value, token.Hex(), err)
+		}
+		log.Infof("Eth approve %v of %s to %s, txHash: %s", value, token.Hex(), spender.Hex(), tx.Hash().Hex())
+		if _, err = this.waitTx(tx); err != nil {
+			return fmt.Errorf("approve %v of %s, waitTx err: %v", value, token.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// polyWrapperEventId returns the topic of the poly wrapper event name
+func polyWrapperEventId(name string) (common.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader(abieth.IPolyWrapperABI))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("parse IPolyWrapperABI err: %v", err)
+	}
+	event, ok := parsed.Events[name]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("no event %s in IPolyWrapperABI", name)
+	}
+	return event.ID, nil
+}
@@ -0,0 +1,286 @@
+package eth
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	abieth "github.com/skyinglyh1/poly_wrapper/abi/eth"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// fakeERC20 is a token which, like USDT, only changes a nonzero allowance to 0
+type fakeERC20 struct {
+	balances   map[common.Address]*big.Int
+	allowances map[string]*big.Int
+}
+
+func newFakeERC20() *fakeERC20 {
+	return &fakeERC20{balances: map[common.Address]*big.Int{}, allowances: map[string]*big.Int{}}
+}
+
+func (this *fakeERC20) balanceOf(addr common.Address) *big.Int {
+	if b, ok := this.balances[addr]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+func (this *fakeERC20) allowance(owner, spender common.Address) *big.Int {
+	if a, ok := this.allowances[owner.Hex()+spender.Hex()]; ok {
+		return a
+	}
+	return new(big.Int)
+}
+
+// transfer moves amount from from to to, spending the allowance of spender if it is not from
+func (this *fakeERC20) transfer(spender, from, to common.Address, amount *big.Int, write bool) error {
+	if this.balanceOf(from).Cmp(amount) < 0 {
+		return fmt.Errorf("transfer amount exceeds balance")
+	}
+	if spender != from && this.allowance(from, spender).Cmp(amount) < 0 {
+		return fmt.Errorf("transfer amount exceeds allowance")
+	}
+	if !write {
+		return nil
+	}
+	if spender != from {
+		this.allowances[from.Hex()+spender.Hex()] = new(big.Int).Sub(this.allowance(from, spender), amount)
+	}
+	this.balances[from] = new(big.Int).Sub(this.balanceOf(from), amount)
+	this.balances[to] = new(big.Int).Add(this.balanceOf(to), amount)
+	return nil
+}
+
+func (this *fakeERC20) contract(t *testing.T) fakeContract {
+	erc20Abi := parseAbi(t, abieth.IERC20ABI)
+	return func(from common.Address, value *big.Int, data []byte, write bool) ([]byte, []*types.Log, error) {
+		method, args := unpackCall(erc20Abi, data)
+		if method == nil {
+			return nil, nil, fmt.Errorf("unknown method")
+		}
+		switch method.Name {
+		case "allowance":
+			out, err := method.Outputs.Pack(this.allowance(args[0].(common.Address), args[1].(common.Address)))
+			return out, nil, err
+		case "balanceOf":
+			out, err := method.Outputs.Pack(this.balanceOf(args[0].(common.Address)))
+			return out, nil, err
+		case "approve":
+			spender, amount := args[0].(common.Address), args[1].(*big.Int)
+			if amount.Sign() != 0 && this.allowance(from, spender).Sign() != 0 {
+				return nil, nil, fmt.Errorf("approve a nonzero allowance")
+			}
+			if write {
+				this.allowances[from.Hex()+spender.Hex()] = amount
+			}
+			out, err := method.Outputs.Pack(true)
+			return out, nil, err
+		}
+		return nil, nil, fmt.Errorf("unexpected method %s", method.Name)
+	}
+}
+
+// fakePolyWrapper pulls the assets of lock and speedUp like PolyWrapper.sol and keeps them
+type fakePolyWrapper struct {
+	node         *fakeEthRpc
+	addr         common.Address
+	owner        common.Address
+	chainId      uint64
+	lockProxy    common.Address
+	feeCollector common.Address
+	tokens       map[common.Address]*fakeERC20
+}
+
+func (this *fakePolyWrapper) pull(from common.Address, value *big.Int, fromAsset common.Address, amount *big.Int, write bool) error {
+	if fromAsset == EthAsset {
+		if value.Cmp(amount) != 0 {
+			return fmt.Errorf("insufficient ether")
+		}
+		return nil
+	}
+	token := this.tokens[fromAsset]
+	if token == nil {
+		return fmt.Errorf("%s is not a token", fromAsset.Hex())
+	}
+	return token.transfer(this.addr, from, this.addr, amount, write)
+}
+
+func (this *fakePolyWrapper) contract(t *testing.T) fakeContract {
+	wrapperAbi := parseAbi(t, abieth.PolyWrapperABI)
+	return func(from common.Address, value *big.Int, data []byte, write bool) ([]byte, []*types.Log, error) {
+		method, args := unpackCall(wrapperAbi, data)
+		if method == nil {
+			return nil, nil, fmt.Errorf("unknown method")
+		}
+		switch method.Name {
+		case "owner":
+			out, err := method.Outputs.Pack(this.owner)
+			return out, nil, err
+		case "chainId":
+			out, err := method.Outputs.Pack(new(big.Int).SetUint64(this.chainId))
+			return out, nil, err
+		case "lockProxy":
+			out, err := method.Outputs.Pack(this.lockProxy)
+			return out, nil, err
+		case "feeCollector":
+			out, err := method.Outputs.Pack(this.feeCollector)
+			return out, nil, err
+		case "setLockProxy", "setFeeCollector":
+			addr := args[0].(common.Address)
+			if from != this.owner {
+				return nil, nil, fmt.Errorf("Ownable: caller is not the owner")
+			}
+			if method.Name == "setLockProxy" && this.node.Contracts[addr] == nil {
+				return nil, nil, fmt.Errorf("not lockproxy")
+			}
+			if write && method.Name == "setLockProxy" {
+				this.lockProxy = addr
+			} else if write {
+				this.feeCollector = addr
+			}
+			return nil, nil, nil
+		case "lock":
+			fromAsset, toChainId, toAddress := args[0].(common.Address), args[1].(uint64), args[2].([]byte)
+			amount, fee, id := args[3].(*big.Int), args[4].(*big.Int), args[5].(*big.Int)
+			if toChainId == this.chainId || toChainId == 0 {
+				return nil, nil, fmt.Errorf("!toChainId")
+			}
+			if amount.Cmp(fee) <= 0 {
+				return nil, nil, fmt.Errorf("amount less than fee")
+			}
+			if err := this.pull(from, value, fromAsset, amount, write); err != nil {
+				return nil, nil, err
+			}
+			event := wrapperAbi.Events["PolyWrapperLock"]
+			logData, err := event.Inputs.NonIndexed().Pack(toChainId, toAddress, new(big.Int).Sub(amount, fee), fee, id)
+			return nil, []*types.Log{{
+				Address: this.addr,
+				Topics:  []common.Hash{event.ID, fromAsset.Hash(), from.Hash()},
+				Data:    logData,
+			}}, err
+		case "speedUp":
+			fromAsset, txHash, fee := args[0].(common.Address), args[1].([]byte), args[2].(*big.Int)
+			if err := this.pull(from, value, fromAsset, fee, write); err != nil {
+				return nil, nil, err
+			}
+			event := wrapperAbi.Events["PolyWrapperSpeedUp"]
+			logData, err := event.Inputs.NonIndexed().Pack(fee)
+			return nil, []*types.Log{{
+				Address: this.addr,
+				Topics:  []common.Hash{event.ID, fromAsset.Hash(), crypto.Keccak256Hash(txHash), from.Hash()},
+				Data:    logData,
+			}}, err
+		case "extractFee":
+			if from != this.feeCollector {
+				return nil, nil, fmt.Errorf("!feeCollector")
+			}
+			token := args[0].(common.Address)
+			if token != EthAsset {
+				return nil, nil, this.tokens[token].transfer(this.addr, this.addr, from, this.tokens[token].balanceOf(this.addr), write)
+			}
+			if write {
+				this.node.Balances[from] = new(big.Int).Add(this.node.balanceOf(from), this.node.balanceOf(this.addr))
+				this.node.Balances[this.addr] = new(big.Int)
+			}
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("unexpected method %s", method.Name)
+	}
+}
+
+func parseAbi(t *testing.T, abiJson string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+// unpackCall decodes the method and the arguments of data, a nil method if it is unknown
+func unpackCall(contractAbi abi.ABI, data []byte) (*abi.Method, []interface{}) {
+	method, err := contractAbi.MethodById(data)
+	if err != nil {
+		return nil, nil
+	}
+	args, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, nil
+	}
+	return method, args
+}
+
+func Test_EthWrapperLock(t *testing.T) {
+	fake := newFakeEthRpc(t)
+	invoker := fake.invoker()
+	user := invoker.Signer.EthAddress()
+	usdt := common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7")
+	token := newFakeERC20()
+	token.balances[user] = big.NewInt(1000)
+	wrapper := &fakePolyWrapper{node: fake, addr: common.HexToAddress("0x2aa63cd0b28fb4c31fa8e4e95ec11815be07b9ac"), chainId: 2, feeCollector: user,
+		tokens: map[common.Address]*fakeERC20{usdt: token}}
+	fake.Contracts[usdt] = token.contract(t)
+	fake.Contracts[wrapper.addr] = wrapper.contract(t)
+	toAddress := common.FromHex("0x7f25d672e8626d2beaa26f2cb40da6b91f40a382")
+
+	// ether is sent as msg.value
+	event, err := invoker.Lock(wrapper.addr, EthAsset, 4, toAddress, big.NewInt(1e6), big.NewInt(1e3), big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Sent) != 1 || fake.Sent[0].Value().Int64() != 1e6 || fake.balanceOf(wrapper.addr).Int64() != 1e6 {
+		t.Fatalf("unexpected ether lock tx: %d txs", len(fake.Sent))
+	}
+	if event.FromAsset != EthAsset || event.Sender != user || event.ToChainId != 4 || !bytes.Equal(event.ToAddress, toAddress) ||
+		event.Net.Int64() != 999000 || event.Fee.Int64() != 1e3 || event.Id.Int64() != 7 {
+		t.Fatalf("unexpected lock event: %+v", event)
+	}
+
+	// an ERC20 is approved first, then the allowance suffices
+	if event, err = invoker.Lock(wrapper.addr, usdt, 4, toAddress, big.NewInt(300), big.NewInt(10), big.NewInt(8)); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Sent) != 3 || fake.Sent[2].Value().Sign() != 0 || event.Net.Int64() != 290 || token.balanceOf(wrapper.addr).Int64() != 300 {
+		t.Fatalf("unexpected token lock: %d txs, event %+v", len(fake.Sent), event)
+	}
+	token.allowances[user.Hex()+wrapper.addr.Hex()] = big.NewInt(500)
+	if _, err = invoker.Lock(wrapper.addr, usdt, 4, toAddress, big.NewInt(300), big.NewInt(10), big.NewInt(9)); err != nil || len(fake.Sent) != 4 {
+		t.Fatalf("expect no approve for a sufficient allowance, got %d txs, err: %v", len(fake.Sent), err)
+	}
+	// a short nonzero allowance is reset to 0 before the approve
+	if _, err = invoker.Lock(wrapper.addr, usdt, 4, toAddress, big.NewInt(300), big.NewInt(10), big.NewInt(10)); err != nil || len(fake.Sent) != 7 {
+		t.Fatalf("expect 2 approves, got %d txs, err: %v", len(fake.Sent), err)
+	}
+
+	speedUp, err := invoker.SpeedUp(wrapper.addr, EthAsset, toAddress, big.NewInt(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.Sent[7].Value().Int64() != 50 || speedUp.Sender != user || speedUp.Efee.Int64() != 50 || speedUp.TxHash != crypto.Keccak256Hash(toAddress) {
+		t.Fatalf("unexpected speedUp: %+v", speedUp)
+	}
+
+	if _, err = invoker.ExtractFee(wrapper.addr, usdt); err != nil {
+		t.Fatal(err)
+	}
+	if token.balanceOf(user).Int64() != 1000 || token.balanceOf(wrapper.addr).Sign() != 0 {
+		t.Fatalf("unexpected token balances after extractFee: %v, %v", token.balanceOf(user), token.balanceOf(wrapper.addr))
+	}
+	wrapper.feeCollector = usdt
+	if _, err = invoker.ExtractFee(wrapper.addr, EthAsset); err == nil || !strings.Contains(err.Error(), "not the fee collector") {
+		t.Fatalf("expect the signer not to be the fee collector, got %v", err)
+	}
+
+	// fee not below amount and a reverted lock fail
+	sent := len(fake.Sent)
+	if _, err = invoker.Lock(wrapper.addr, EthAsset, 4, toAddress, big.NewInt(10), big.NewInt(10), big.NewInt(11)); err == nil {
+		t.Fatal("expect a fee equal to amount to fail")
+	}
+	if _, err = invoker.Lock(wrapper.addr, EthAsset, 2, toAddress, big.NewInt(100), big.NewInt(10), big.NewInt(11)); err == nil || len(fake.Sent) != sent {
+		t.Fatalf("expect a lock to the source chain to fail, got %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"strings"
+)
+
+const (
+	// far below the 102400 bytes of a NEO tx, so a failed tx does not take too many bindings along
+	DefaultBatchMaxScriptSize = 16 * 1024
+	// a batch within the free gas of a tx pays no system fee. A binding consuming more is sent
+	// alone and pays the system fee, see BatchLimits
+	DefaultBatchMaxGas = FreeGas
+)
+
+// BatchLimits bound the txs sent by BindBatch, by the size of their script and the gas it consumes.
+// MaxGas only bounds txs of several bindings, a single binding consuming more than MaxGas is sent
+// alone and pays the system fee above the free gas
+type BatchLimits struct {
+	MaxScriptSize int
+	MaxGas        helper.Fixed8
+}
+
+func DefaultBatchLimits() *BatchLimits {
+	return &BatchLimits{
+		MaxScriptSize: DefaultBatchMaxScriptSize,
+		MaxGas:        helper.Fixed8FromInt64(DefaultBatchMaxGas),
+	}
+}
+
+// BatchResult is the outcome of BindBatch. A binding succeeded if it has a TxHash and no Err
+type BatchResult struct {
+	TxHashs  []string   `json:"txHashs"`
+	Bindings []*Binding `json:"bindings"`
+}
+
+func (this *BatchResult) Succeeded() []*Binding {
+	res := make([]*Binding, 0)
+	for _, b := range this.Bindings {
+		if b.TxHash != "" && b.Err == "" {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func (this *BatchResult) Failed() []*Binding {
+	res := make([]*Binding, 0)
+	for _, b := range this.Bindings {
+		if b.TxHash == "" || b.Err != "" {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// Script builds the bindProxyHash or bindAssetHash call setting b to Desired
+func (this *Binding) Script() ([]byte, error) {
+	desired, err := hex.DecodeString(this.Desired)
+	if err != nil {
+		return nil, fmt.Errorf("desired %s: %v", this.Desired, err)
+	}
+	switch this.Kind {
+	case BindingProxy:
+		return BindProxyHashScript(this.LockProxy, this.ToChainId, desired), nil
+	case BindingAsset:
+		return BindAssetHashScript(this.LockProxy, this.FromAsset, this.ToChainId, desired), nil
+	}
+	return nil, fmt.Errorf("unknown binding kind %s", this.Kind)
+}
+
+// BindBatch sends bindings as few txs of several calls each. The bindings are packed in order up
+// to limits.MaxScriptSize, then a batch is run through invokescript and split in halves while it
+// consumes more than limits.MaxGas or FAULTs, so a binding which FAULTs ends up failing alone,
+// and a binding consuming more than limits.MaxGas is sent alone with its system fee.
+// Bindings returning false in invokescript are left out, and every call of a sent tx must return
+// true in its application log. A failed binding gets Err, the others of its tx still succeed.
+// The error is only for a sender which can not be used
+func (this *NeoInvoker) BindBatch(bindings []*Binding, limits *BatchLimits) (*BatchResult, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("[BindBatch], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	fromUint160, err := helper.UInt160FromBytes(from)
+	if err != nil {
+		return nil, fmt.Errorf("[BindBatch], Uint160FromBytes err: %v", err)
+	}
+	if limits == nil {
+		limits = DefaultBatchLimits()
+	}
+	res := &BatchResult{TxHashs: make([]string, 0), Bindings: bindings}
+
+	scripts := make(map[*Binding][]byte)
+	batches := make([][]*Binding, 0)
+	var batch []*Binding
+	size := 0
+	for _, b := range bindings {
+		b.TxHash, b.Err = "", ""
+		script, err := b.Script()
+		if err != nil {
+			b.Err = err.Error()
+			continue
+		}
+		if len(batch) > 0 && size+len(script) > limits.MaxScriptSize {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		scripts[b] = script
+		batch = append(batch, b)
+		size += len(script)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	for len(batches) > 0 {
+		batch, batches = batches[0], batches[1:]
+		script := batchScript(batch, scripts)
+		kept, err := this.checkBatch(script, batch, fromUint160, limits)
+		if err != nil {
+			if len(batch) > 1 {
+				log.Warnf("[BindBatch], batch of %d bindings split in halves: %v", len(batch), err)
+				batches = append([][]*Binding{batch[:len(batch)/2], batch[len(batch)/2:]}, batches...)
+				continue
+			}
+			batch[0].Err = err.Error()
+			continue
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		if len(kept) < len(batch) {
+			// check again without the bindings returning false, the others may consume less
+			batches = append([][]*Binding{kept}, batches...)
+			continue
+		}
+		txHash, err := this.sendBatch(script, batch, fromUint160)
+		if txHash != "" {
+			res.TxHashs = append(res.TxHashs, txHash)
+		}
+		if err != nil {
+			log.Errorf("[BindBatch], batch of %d bindings, tx: %s, err: %v", len(batch), txHash, err)
+		}
+	}
+	log.Infof("neo lock proxy batch binding, %d of %d bindings done in %d txs", len(res.Succeeded()), len(bindings), len(res.TxHashs))
+	return res, nil
+}
+
+func batchScript(batch []*Binding, scripts map[*Binding][]byte) []byte {
+	buf := new(bytes.Buffer)
+	for _, b := range batch {
+		buf.Write(scripts[b])
+	}
+	return buf.Bytes()
+}
+
+// checkBatch runs script and returns the bindings of batch whose call returned true, the others
+// get Err. It fails if the script FAULTs, or consumes more than limits.MaxGas for several bindings
+func (this *NeoInvoker) checkBatch(script []byte, batch []*Binding, from helper.UInt160, limits *BatchLimits) ([]*Binding, error) {
+	response, err := this.InvokeScript(script, from)
+	if err != nil {
+		return nil, fmt.Errorf("InvokeScript err: %w", err)
+	}
+	gasConsumed, err := helper.Fixed8FromString(response.GasConsumed)
+	if err != nil {
+		return nil, fmt.Errorf("gas_consumed: %s, Fixed8FromString err: %v", response.GasConsumed, err)
+	}
+	if gasConsumed.GreaterThan(limits.MaxGas) {
+		if len(batch) > 1 {
+			return nil, fmt.Errorf("gas consumed %s above %s", gasConsumed.String(), limits.MaxGas.String())
+		}
+		log.Infof("[BindBatch], %s consumes %s above %s, sent alone with system fee %s", batch[0].String(),
+			gasConsumed.String(), limits.MaxGas.String(), SystemFee(gasConsumed).String())
+	}
+	items, err := response.StackItems(len(batch))
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]*Binding, 0, len(batch))
+	for i, b := range batch {
+		if err := returnedTrue(items[i]); err != nil {
+			b.Err = fmt.Sprintf("bind%sHash in invokescript %v", strings.Title(b.Kind), err)
+			continue
+		}
+		kept = append(kept, b)
+	}
+	return kept, nil
+}
+
+// sendBatch sends script as one tx and checks the result of each binding of batch, which all get
+// the tx hash once it is sent
+func (this *NeoInvoker) sendBatch(script []byte, batch []*Binding, from helper.UInt160) (string, error) {
+	fail := func(err error) error {
+		for _, b := range batch {
+			b.Err = err.Error()
+		}
+		return err
+	}
+	itx, _, err := this.MakeInvocationTx(script, from)
+	if err != nil {
+		return "", fail(fmt.Errorf("MakeInvocationTx error: %w", err))
+	}
+	if err = signer.SignNeoTx(itx, this.Signer); err != nil {
+		return "", fail(fmt.Errorf("SignNeoTx error: %s", err))
+	}
+	rawTxString := itx.RawTransactionString()
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return "", fail(fmt.Errorf("SendRawTransaction error: %s,  RawTransactionString: %s", err, rawTxString))
+	}
+	txHash := itx.HashString()
+	log.Infof("Neo batch of %d bindings, txHash: %s", len(batch), txHash)
+	for _, b := range batch {
+		b.TxHash = txHash
+	}
+	conf, err := this.waitTx(txHash)
+	if err != nil {
+		return txHash, fail(fmt.Errorf("waitTx error: %w", err))
+	}
+	if len(conf.Stack) != len(batch) {
+		return txHash, fail(fmt.Errorf("expect %d results in application log, got %d", len(batch), len(conf.Stack)))
+	}
+	for i, b := range batch {
+		item, err := ParseStackItem(conf.Stack[i])
+		if err != nil {
+			b.Err = fmt.Sprintf("result %d: %v", i, err)
+			continue
+		}
+		if err = returnedTrue(item); err != nil {
+			b.Err = fmt.Sprintf("bind%sHash %v", strings.Title(b.Kind), err)
+		}
+	}
+	return txHash, nil
+}
+
+func returnedTrue(item *StackItem) error {
+	ok, err := item.Bool()
+	if err != nil {
+		return fmt.Errorf("result %v", err)
+	}
+	if !ok {
+		return fmt.Errorf("returned false")
+	}
+	return nil
+}
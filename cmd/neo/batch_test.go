@@ -0,0 +1,128 @@
+package neo
+
+import (
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"strings"
+	"testing"
+)
+
+func testBindings(t *testing.T, assets int) []*Binding {
+	lockProxy, err := ParseNeoHash(testLockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toProxy := make([]byte, 20)
+	toProxy[0] = 0xff
+	bindings := []*Binding{newBinding(BindingProxy, lockProxy, nil, 2, nil, toProxy)}
+	for i := 0; i < assets; i++ {
+		from, to := make([]byte, 20), make([]byte, 20)
+		from[19], to[19] = byte(i+1), byte(i+1)
+		bindings = append(bindings, newBinding(BindingAsset, lockProxy, from, 2, nil, to))
+	}
+	return bindings
+}
+
+func Test_BindBatch(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	// the proxy binding is refused, three asset bindings fit in the free gas of a tx
+	fake.Reads["bindProxyHash"] = boolItem(false)
+	fake.Reads["bindAssetHash"] = boolItem(true)
+	fake.Gas["bindAssetHash"] = 3
+	// the asset bindings return false on chain from the second tx on
+	fake.OnSent = func(calls []*ContractCall) {
+		if len(fake.Sent) == 2 {
+			fake.Reads["bindAssetHash"] = boolItem(false)
+		}
+	}
+	bindings := testBindings(t, 7)
+
+	res, err := invoker.BindBatch(bindings, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Sent) != 2 || len(res.TxHashs) != 2 {
+		t.Fatalf("expect 2 txs, sent %d, result %v", len(fake.Sent), res.TxHashs)
+	}
+	if calls := fake.sentCalls(0); len(calls) != 3 || calls[0].Method != "bindAssetHash" {
+		t.Fatalf("unexpected calls of the first tx: %+v", calls)
+	}
+	if calls := fake.sentCalls(1); len(calls) != 2 {
+		t.Fatalf("unexpected calls of the second tx: %+v", calls)
+	}
+	if succeeded := res.Succeeded(); len(succeeded) != 3 || succeeded[0] != bindings[1] || succeeded[0].TxHash != res.TxHashs[0] {
+		t.Fatalf("unexpected succeeded bindings: %+v", succeeded)
+	}
+	if len(res.Failed()) != 5 {
+		t.Fatalf("expect 5 failed bindings, got %+v", res.Failed())
+	}
+	// refused in invokescript
+	for _, b := range []*Binding{bindings[0], bindings[6], bindings[7]} {
+		if b.TxHash != "" || !strings.Contains(b.Err, "in invokescript returned false") {
+			t.Fatalf("unexpected result of %s: %s, %s", b.String(), b.TxHash, b.Err)
+		}
+	}
+	// refused on chain
+	for _, b := range []*Binding{bindings[4], bindings[5]} {
+		if b.TxHash != res.TxHashs[1] || b.Err != "bindAssetHash returned false" {
+			t.Fatalf("unexpected result of %s: %s, %s", b.String(), b.TxHash, b.Err)
+		}
+	}
+}
+
+func Test_BindBatch_Fault(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	fake.Faults["bindProxyHash"] = "not operator"
+	fake.Reads["bindAssetHash"] = boolItem(true)
+	bindings := testBindings(t, 3)
+	script, _ := bindings[1].Script()
+	// two asset bindings per tx
+	limits := &BatchLimits{MaxScriptSize: 2 * len(script), MaxGas: helper.Fixed8FromInt64(DefaultBatchMaxGas)}
+
+	res, err := invoker.BindBatch(bindings, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the FAULTing proxy binding is split off its batch and fails alone
+	if !strings.Contains(bindings[0].Err, "not operator") || bindings[0].TxHash != "" {
+		t.Fatalf("unexpected result of the proxy binding: %s, %s", bindings[0].TxHash, bindings[0].Err)
+	}
+	if len(fake.Sent) != 2 || len(res.Succeeded()) != 3 {
+		t.Fatalf("expect 3 asset bindings in 2 txs, sent %d, failed %+v", len(fake.Sent), res.Failed())
+	}
+	if bindings[1].TxHash != res.TxHashs[0] || bindings[2].TxHash != res.TxHashs[1] || bindings[3].TxHash != res.TxHashs[1] {
+		t.Fatalf("unexpected tx hashes: %v", res.TxHashs)
+	}
+}
+
+func Test_BindBatch_OverGas(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	fake.Reads["bindProxyHash"] = boolItem(true)
+	fake.Reads["bindAssetHash"] = boolItem(true)
+	// an asset binding alone is above the free gas of a tx
+	fake.Gas["bindAssetHash"] = 12
+	bindings := testBindings(t, 2)
+
+	res, err := invoker.BindBatch(bindings, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Failed()) != 0 || len(fake.Sent) != 3 {
+		t.Fatalf("expect each binding in its own tx, sent %d, failed %+v", len(fake.Sent), res.Failed())
+	}
+	for i, itx := range fake.Sent {
+		calls := fake.sentCalls(i)
+		if len(calls) != 1 {
+			t.Fatalf("unexpected calls of tx %d: %+v", i, calls)
+		}
+		if paid := itx.Gas.GreaterThan(helper.Zero); paid != (calls[0].Method == "bindAssetHash") {
+			t.Fatalf("unexpected system fee %s of %s", itx.Gas.String(), calls[0].Method)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package neo
+
+import (
+	"context"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultConfirmTimeout = 5 * time.Minute
+	DefaultConfirmations  = 1
+
+	minPollInterval = 100 * time.Millisecond
+	maxPollInterval = 5 * time.Second
+	// a tx missing from both the chain and the mempool while this many blocks are added is reported
+	// as dropped, a node which did not receive the tx yet or lags behind is not taken for a drop
+	droppedBlocks = 3
+
+	VMStateHalt  = "HALT"
+	VMStateFault = "FAULT"
+)
+
+// TxConfirmation is the on chain result of a NEO tx
+type TxConfirmation struct {
+	TxHash        string
+	Height        uint32
+	Confirmations uint32
+	VMState       string
+	GasConsumed   string
+	// Stack is the result of the Application trigger, one item per call of the script
+	Stack         []models.InvokeStack
+	Notifications []models.RpcNotification
+}
+
+// appLogResult is the getapplicationlog result with the stack decoded like invokescript,
+// models.RpcExecution takes stack values as strings and fails on Boolean results
+type appLogResult struct {
+	TxId       string `json:"txid"`
+	Executions []struct {
+		Trigger       string                   `json:"trigger"`
+		VMState       string                   `json:"vmstate"`
+		GasConsumed   string                   `json:"gas_consumed"`
+		Stack         []models.InvokeStack     `json:"stack"`
+		Notifications []models.RpcNotification `json:"notifications"`
+	} `json:"executions"`
+}
+
+type TxTimeoutError struct {
+	TxHash string
+	Waited time.Duration
+}
+
+func (this *TxTimeoutError) Error() string {
+	return fmt.Sprintf("tx %s not confirmed after %s", this.TxHash, this.Waited)
+}
+
+type TxDroppedError struct {
+	TxHash string
+}
+
+func (this *TxDroppedError) Error() string {
+	return fmt.Sprintf("tx %s is neither on chain nor in mempool", this.TxHash)
+}
+
+type TxFaultError struct {
+	TxHash      string
+	GasConsumed string
+}
+
+func (this *TxFaultError) Error() string {
+	return fmt.Sprintf("tx %s ends in %s, gas consumed: %s", this.TxHash, VMStateFault, this.GasConsumed)
+}
+
+// ConfirmTx polls until txHash is buried under confirmations blocks, backing off between polls.
+// It returns *TxTimeoutError after timeout, *TxDroppedError once the tx stayed out of the chain and
+// the mempool for droppedBlocks blocks, and *TxFaultError together with the confirmation if the VM ends in FAULT
+func (this *NeoInvoker) ConfirmTx(ctx context.Context, txHash string, timeout time.Duration, confirmations uint32) (*TxConfirmation, error) {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	interval := minPollInterval
+	// missingSince is the block count when the tx was first found missing, 0 while it is seen
+	missingSince := 0
+	for {
+		conf, inMempool, err := this.pollTx(txHash)
+		if err != nil {
+			log.Debugf("[ConfirmTx], poll tx %s err: %v", txHash, err)
+		} else if conf != nil && conf.Confirmations >= confirmations {
+			return this.finishConfirm(conf)
+		} else if conf == nil && !inMempool {
+			if count := this.Cli.GetBlockCount(); count.HasError() {
+				log.Debugf("[ConfirmTx], poll tx %s, GetBlockCount err: %s", txHash, count.Error.Message)
+			} else if missingSince == 0 {
+				missingSince = count.Result
+			} else if count.Result-missingSince >= droppedBlocks {
+				return nil, &TxDroppedError{TxHash: txHash}
+			}
+		} else {
+			missingSince = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, &TxTimeoutError{TxHash: txHash, Waited: time.Since(start)}
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
+		if interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
+
+// pollTx returns the confirmation if txHash is on chain, otherwise whether it is still in mempool
+func (this *NeoInvoker) pollTx(txHash string) (*TxConfirmation, bool, error) {
+	res := this.Cli.GetTransactionHeight(txHash)
+	if res.HasError() {
+		if !strings.Contains(res.Error.Message, "Unknown") {
+			return nil, false, fmt.Errorf("GetTransactionHeight err: %s", res.Error.Message)
+		}
+		pool := this.Cli.GetRawMemPool()
+		if pool.HasError() {
+			return nil, false, fmt.Errorf("GetRawMemPool err: %s", pool.Error.Message)
+		}
+		for _, h := range pool.Result {
+			if sameTxHash(h, txHash) {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+	if res.Result <= 0 {
+		return nil, true, nil
+	}
+	count := this.Cli.GetBlockCount()
+	if count.HasError() {
+		return nil, false, fmt.Errorf("GetBlockCount err: %s", count.Error.Message)
+	}
+	conf := &TxConfirmation{TxHash: txHash, Height: uint32(res.Result)}
+	if count.Result > res.Result {
+		conf.Confirmations = uint32(count.Result - res.Result)
+	}
+	return conf, false, nil
+}
+
+// finishConfirm fills the vm state from the application log
+func (this *NeoInvoker) finishConfirm(conf *TxConfirmation) (*TxConfirmation, error) {
+	// post rather than call, the log is read from the node which confirmed the tx
+	appLog := &appLogResult{}
+	if _, err := this.post("getapplicationlog", []interface{}{conf.TxHash}, appLog); err != nil {
+		return conf, fmt.Errorf("[ConfirmTx], tx %s confirmed, GetApplicationLog err: %v", conf.TxHash, err)
+	}
+	for _, execution := range appLog.Executions {
+		if execution.Trigger != "" && execution.Trigger != "Application" {
+			continue
+		}
+		conf.GasConsumed = execution.GasConsumed
+		conf.Stack = append(conf.Stack, execution.Stack...)
+		conf.Notifications = append(conf.Notifications, execution.Notifications...)
+		if strings.Contains(execution.VMState, VMStateFault) {
+			conf.VMState = VMStateFault
+		} else if conf.VMState == "" {
+			conf.VMState = VMStateHalt
+		}
+	}
+	log.Infof("capture neo tx %s, height: %d, vm state: %s", conf.TxHash, conf.Height, conf.VMState)
+	if conf.VMState == VMStateFault {
+		method := ""
+		if raw := this.Cli.GetRawTransaction(conf.TxHash); !raw.HasError() {
+			method = scriptMethods(helper.HexToBytes(raw.Result.Script))
+		}
+		return conf, newContractFaultError(method, conf.TxHash, conf.Notifications,
+			&TxFaultError{TxHash: conf.TxHash, GasConsumed: conf.GasConsumed})
+	}
+	return conf, nil
+}
+
+// waitTx confirms a tx sent by a write method with the invoker defaults
+func (this *NeoInvoker) waitTx(txHash string) (*TxConfirmation, error) {
+	timeout := this.ConfirmTimeout
+	if timeout == 0 {
+		timeout = DefaultConfirmTimeout
+	}
+	return this.ConfirmTx(context.Background(), txHash, timeout, this.Confirmations)
+}
+
+func sameTxHash(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "0x"), strings.TrimPrefix(b, "0x"))
+}
@@ -0,0 +1,128 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/joeqian10/neo-gogogo/rpc"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testTxHash = "0x9c1e5d6e0d0d6b1aa4b3b4d6f3c6a2b0c7f8e9d0a1b2c3d4e5f60718293a4b5c"
+
+// newConfirmInvoker serves each rpc method from results, an error result is sent as rpc error
+// and a func result is called for each request
+func newConfirmInvoker(t *testing.T, results map[string]interface{}) *NeoInvoker {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string `json:"method"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+		result := results[req.Method]
+		if f, ok := result.(func() interface{}); ok {
+			result = f()
+		}
+		switch res := result.(type) {
+		case error:
+			resp["error"] = map[string]interface{}{"code": -100, "message": res.Error()}
+		default:
+			resp["result"] = res
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return &NeoInvoker{Cli: rpc.NewClient(srv.URL)}
+}
+
+func applicationLog(vmState string) map[string]interface{} {
+	return map[string]interface{}{
+		"txid": testTxHash,
+		"executions": []interface{}{
+			map[string]interface{}{"trigger": "Application", "vmstate": vmState, "gas_consumed": "2.5", "notifications": []interface{}{}},
+		},
+	}
+}
+
+func Test_ConfirmTx_Halt(t *testing.T) {
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"gettransactionheight": 10,
+		"getblockcount":        12,
+		"getapplicationlog":    applicationLog("HALT"),
+	})
+	conf, err := invoker.ConfirmTx(context.Background(), testTxHash, time.Second, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Height != 10 || conf.Confirmations != 2 || conf.VMState != VMStateHalt || conf.GasConsumed != "2.5" {
+		t.Fatalf("unexpected confirmation: %+v", conf)
+	}
+}
+
+func Test_ConfirmTx_Fault(t *testing.T) {
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"gettransactionheight": 10,
+		"getblockcount":        11,
+		"getapplicationlog":    applicationLog("FAULT, BREAK"),
+	})
+	conf, err := invoker.ConfirmTx(context.Background(), testTxHash, time.Second, 1)
+	var faultErr *TxFaultError
+	if !errors.As(err, &faultErr) {
+		t.Fatalf("expect TxFaultError, got: %v", err)
+	}
+	if conf == nil || conf.VMState != VMStateFault {
+		t.Fatalf("unexpected confirmation: %+v", conf)
+	}
+}
+
+func Test_ConfirmTx_Dropped(t *testing.T) {
+	height := 10
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"gettransactionheight": errors.New("Unknown transaction"),
+		"getrawmempool":        []string{},
+		"getblockcount": func() interface{} {
+			height++
+			return height
+		},
+	})
+	_, err := invoker.ConfirmTx(context.Background(), testTxHash, 5*time.Second, 1)
+	var droppedErr *TxDroppedError
+	if !errors.As(err, &droppedErr) {
+		t.Fatalf("expect TxDroppedError, got: %v", err)
+	}
+
+	// missing while no block is added, as from a node which has not received the tx yet, is not a drop
+	invoker = newConfirmInvoker(t, map[string]interface{}{
+		"gettransactionheight": errors.New("Unknown transaction"),
+		"getrawmempool":        []string{},
+		"getblockcount":        10,
+	})
+	_, err = invoker.ConfirmTx(context.Background(), testTxHash, time.Second, 1)
+	var timeoutErr *TxTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expect TxTimeoutError, got: %v", err)
+	}
+}
+
+func Test_ConfirmTx_Timeout(t *testing.T) {
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"gettransactionheight": errors.New("Unknown transaction"),
+		"getrawmempool":        []string{testTxHash},
+	})
+	_, err := invoker.ConfirmTx(context.Background(), testTxHash, 300*time.Millisecond, 1)
+	var timeoutErr *TxTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expect TxTimeoutError, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = invoker.ConfirmTx(ctx, testTxHash, time.Second, 1); err != context.Canceled {
+		t.Fatalf("expect context.Canceled, got: %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package neo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"io/ioutil"
+	"math/big"
+	"strings"
+)
+
+// NeoDeployment is the record of DeployWrapper
+type NeoDeployment struct {
+	Wrapper NeoHash                 `json:"wrapper"`
+	Meta    *config.NeoContractMeta `json:"meta"`
+	// DeployTxHash is empty if the contract was deployed already
+	DeployTxHash string `json:"deployTxHash,omitempty"`
+	// Txs are the setLockProxy and setFeeCollector sent, none if both were set already
+	Txs   []*OwnerTxResult `json:"txs"`
+	State *WrapperState    `json:"state"`
+}
+
+// ContractHash is the hash of the contract deployed with code, as stored
+func ContractHash(code []byte) (NeoHash, error) {
+	hash, err := helper.BytesToScriptHash(code)
+	if err != nil {
+		return nil, err
+	}
+	return NeoHash(hash.Bytes()), nil
+}
+
+// DeployScript builds the Neo.Contract.Create script of code with meta
+func DeployScript(code []byte, meta *config.NeoContractMeta) ([]byte, error) {
+	args, err := contractArgs(code, meta)
+	if err != nil {
+		return nil, err
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	if err = scriptBuilder.EmitSysCall("Neo.Contract.Create", args); err != nil {
+		return nil, err
+	}
+	return scriptBuilder.ToArray(), nil
+}
+
+// contractArgs are the arguments of Neo.Contract.Create and Neo.Contract.Migrate
+func contractArgs(code []byte, meta *config.NeoContractMeta) ([]sc.ContractParameter, error) {
+	if meta == nil || meta.Name == "" || meta.Version == "" {
+		return nil, fmt.Errorf("contract meta has no name or version")
+	}
+	paramTypes, err := hex.DecodeString(meta.ParamTypes)
+	if err != nil {
+		return nil, fmt.Errorf("paramTypes %s: %v", meta.ParamTypes, err)
+	}
+	returnType, err := hex.DecodeString(meta.ReturnType)
+	if err != nil || len(returnType) != 1 {
+		return nil, fmt.Errorf("returnType %s is not one hex byte", meta.ReturnType)
+	}
+	property := sc.NoProperty
+	if meta.HasStorage {
+		property |= sc.HasStorage
+	}
+	if meta.HasDynamicInvoke {
+		property |= sc.HasDynamicInvoke
+	}
+	if meta.IsPayable {
+		property |= sc.Payable
+	}
+	return []sc.ContractParameter{
+		{Type: sc.ByteArray, Value: code},
+		{Type: sc.ByteArray, Value: paramTypes},
+		{Type: sc.Integer, Value: *big.NewInt(int64(returnType[0]))},
+		{Type: sc.Integer, Value: *big.NewInt(int64(property))},
+		{Type: sc.String, Value: meta.Name},
+		{Type: sc.String, Value: meta.Version},
+		{Type: sc.String, Value: meta.Author},
+		{Type: sc.String, Value: meta.Email},
+		{Type: sc.String, Value: meta.Description},
+	}, nil
+}
+
+// ContractExists reads the contract of hash through getcontractstate
+func (this *NeoInvoker) ContractExists(hash []byte) (bool, error) {
+	state, err := this.ContractState(hash)
+	if err != nil {
+		return false, fmt.Errorf("[ContractExists], %v", err)
+	}
+	return state != nil, nil
+}
+
+// ContractState returns the getcontractstate of hash, nil if there is no such contract
+func (this *NeoInvoker) ContractState(hash []byte) (*models.RpcContractState, error) {
+	state := &models.RpcContractState{}
+	err := this.call("getcontractstate", []interface{}{NeoHash(hash).BigEndian()}, state)
+	if err != nil {
+		if strings.Contains(err.Error(), "Unknown contract") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getcontractstate of %s err: %v", NeoHash(hash).String(), err)
+	}
+	return state, nil
+}
+
+// Deploy creates the contract of code with meta unless it exists already. It returns the contract
+// hash and the deploy tx hash, empty if nothing was sent
+func (this *NeoInvoker) Deploy(code []byte, meta *config.NeoContractMeta) (NeoHash, string, error) {
+	hash, err := ContractHash(code)
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy], ContractHash err: %v", err)
+	}
+	exists, err := this.ContractExists(hash)
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy], %w", err)
+	}
+	if exists {
+		log.Infof("Neo contract %s deployed already", hash.String())
+		return hash, "", nil
+	}
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	fromUint160, err := helper.UInt160FromBytes(from)
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy], Uint160FromBytes err: %v", err)
+	}
+	script, err := DeployScript(code, meta)
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy], %v", err)
+	}
+
+	// create an InvocationTransaction
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy] MakeInvocationTx error: %w", err)
+	}
+	// sign transaction
+	err = signer.SignNeoTx(itx, this.Signer)
+	if err != nil {
+		return nil, "", fmt.Errorf("[Deploy] SignNeoTx error: %s", err)
+	}
+
+	rawTxString := itx.RawTransactionString()
+
+	// send the raw transaction
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return nil, "", fmt.Errorf("[Deploy] SendRawTransaction error: %s,  RawTransactionString: %s",
+			err, rawTxString)
+	}
+	log.Infof("Neo deploy %s, contract hash: %s, txHash: %s", meta.Name, hash.String(), itx.HashString())
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return nil, "", fmt.Errorf("[Deploy] waitTx error: %w", err)
+	}
+	return hash, itx.HashString(), nil
+}
+
+// DeployWrapper deploys the poly wrapper of code unless it exists, sets its lock proxy and fee
+// collector where they differ and checks the final state. Running it again sends nothing
+func (this *NeoInvoker) DeployWrapper(code []byte, meta *config.NeoContractMeta, neoLockProxy []byte, feeCollector []byte) (*NeoDeployment, error) {
+	wrapper, txHash, err := this.Deploy(code, meta)
+	if err != nil {
+		return nil, fmt.Errorf("[DeployWrapper], %w", err)
+	}
+	res := &NeoDeployment{Wrapper: wrapper, Meta: meta, DeployTxHash: txHash, Txs: make([]*OwnerTxResult, 0)}
+
+	lockProxy, err := this.LockProxy(wrapper)
+	if err != nil {
+		return res, fmt.Errorf("[DeployWrapper], %w", err)
+	}
+	if !lockProxy.Equal(neoLockProxy) {
+		tx, err := this.SetLockProxy(wrapper, neoLockProxy)
+		if err != nil {
+			return res, fmt.Errorf("[DeployWrapper], %w", err)
+		}
+		res.Txs = append(res.Txs, tx)
+	}
+	collector, err := this.FeeCollector(wrapper)
+	if err != nil {
+		return res, fmt.Errorf("[DeployWrapper], %w", err)
+	}
+	if !collector.Equal(feeCollector) {
+		tx, err := this.SetFeeCollector(wrapper, feeCollector)
+		if err != nil {
+			return res, fmt.Errorf("[DeployWrapper], %w", err)
+		}
+		res.Txs = append(res.Txs, tx)
+	}
+
+	if res.State, err = this.WrapperState(wrapper, nil); err != nil {
+		return res, fmt.Errorf("[DeployWrapper], %w", err)
+	}
+	if !res.State.LockProxy.Equal(neoLockProxy) {
+		return res, fmt.Errorf("[DeployWrapper], lock proxy of %s is %s after deployment, expect %s",
+			wrapper.String(), res.State.LockProxy.String(), NeoHash(neoLockProxy).String())
+	}
+	if !res.State.FeeCollector.Equal(feeCollector) {
+		return res, fmt.Errorf("[DeployWrapper], fee collector of %s is %s after deployment, expect %s",
+			wrapper.String(), res.State.FeeCollector.String(), NeoHash(feeCollector).String())
+	}
+	log.Infof("Neo poly wrapper %s ready, owner: %s, lock proxy: %s, fee collector: %s", wrapper.String(),
+		res.State.Owner.Address(), res.State.LockProxy.String(), res.State.FeeCollector.Address())
+	return res, nil
+}
+
+// DeployWrapperFromConfig runs DeployWrapper with NeoWrapperAvm, NeoWrapperMeta, NeoLockProxy and
+// NeoFeeCollector of cfg
+func (this *NeoInvoker) DeployWrapperFromConfig(cfg *config.TestConfig) (*NeoDeployment, error) {
+	if cfg.NeoWrapperAvm == "" || cfg.NeoLockProxy == "" || cfg.NeoFeeCollector == "" {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], neoWrapperAvm, neoLockProxy and neoFeeCollector are all needed")
+	}
+	code, err := ioutil.ReadFile(cfg.NeoWrapperAvm)
+	if err != nil {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], read avm err: %v", err)
+	}
+	lockProxy, err := ParseNeoHash(cfg.NeoLockProxy)
+	if err != nil {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], neoLockProxy %s: %v", cfg.NeoLockProxy, err)
+	}
+	collector, err := ParseNeoHash(cfg.NeoFeeCollector)
+	if err != nil {
+		return nil, fmt.Errorf("[DeployWrapperFromConfig], neoFeeCollector %s: %v", cfg.NeoFeeCollector, err)
+	}
+	return this.DeployWrapper(code, cfg.NeoWrapperMeta, lockProxy, collector)
+}
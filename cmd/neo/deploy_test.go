@@ -0,0 +1,61 @@
+package neo
+
+import (
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"strings"
+	"testing"
+)
+
+func Test_DeployNeo_Wrapper(t *testing.T) {
+	cfg := config.NewTestConfig()
+	if err := cfg.Init("../../config.json"); err != nil {
+		t.Fatal(err)
+	}
+	cfg.NeoWrapperAvm = "../../" + cfg.NeoWrapperAvm
+	cfg.NeoLockProxy = testLockHash
+	collector, _ := wallet.NewAccount()
+	cfg.NeoFeeCollector = collector.Address
+
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	owner, _ := ParseNeoAddr(acc.Address)
+	fake.Reads["owner"] = hashItem(owner)
+	fake.OnSent = func(calls []*ContractCall) {
+		switch calls[0].Method {
+		case "setLockProxy":
+			fake.Reads["lockProxy"] = hashItem(calls[0].Args[0].Bytes)
+			fake.Storage[strings.TrimPrefix(NeoHash(calls[0].ScriptHash).BigEndian(), "0x")+NeoLockProxyKey] = NeoHash(calls[0].Args[0].Bytes).LittleEndian()
+		case "setFeeCollector":
+			fake.Reads["feeCollector"] = hashItem(calls[0].Args[0].Bytes)
+		}
+	}
+
+	res, err := invoker.DeployWrapperFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the hash of src/neo/neo_wrapper.avm in neo_wrapper.abi.json
+	if res.Wrapper.String() != "0xb88424b36a5548be2448682fcab53f49596f0dff" || res.DeployTxHash == "" {
+		t.Fatalf("unexpected deployment %s, tx %s", res.Wrapper.String(), res.DeployTxHash)
+	}
+	calls := fake.sentCalls(0)
+	if len(calls) != 1 || calls[0].Syscall != contractCreateApi || string(calls[0].Args[4].Bytes) != cfg.NeoWrapperMeta.Name {
+		t.Fatalf("unexpected deploy calls: %+v", calls)
+	}
+	if len(res.Txs) != 2 || res.Txs[0].Method != "setLockProxy" || res.Txs[1].Method != "setFeeCollector" {
+		t.Fatalf("unexpected init txs: %+v", res.Txs)
+	}
+	if res.State.LockProxy.String() != testLockHash || res.State.FeeCollector.Address() != cfg.NeoFeeCollector {
+		t.Fatalf("unexpected state: %+v", res.State)
+	}
+
+	// a second run finds everything in place
+	if res, err = invoker.DeployWrapperFromConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Sent) != 3 || res.DeployTxHash != "" || len(res.Txs) != 0 {
+		t.Fatalf("second run sent %d txs, deployment %+v", len(fake.Sent), res)
+	}
+}
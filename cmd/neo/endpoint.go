@@ -0,0 +1,198 @@
+package neo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/rpc"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxHeightLag   = 5
+	DefaultMaxLatency     = 3 * time.Second
+	DefaultHealthInterval = 30 * time.Second
+)
+
+// NeoEndpoint is the health of a NEO RPC node as of its last check
+type NeoEndpoint struct {
+	Url       string        `json:"url"`
+	Height    uint32        `json:"height"`
+	Latency   time.Duration `json:"latency"`
+	Healthy   bool          `json:"healthy"`
+	Err       string        `json:"err,omitempty"`
+	CheckedAt time.Time     `json:"checkedAt"`
+}
+
+// EndpointPool picks the NEO RPC node an invoker talks to. A node is healthy if it answers
+// getblockcount within MaxLatency and is at most MaxHeightLag blocks behind the highest node,
+// the first healthy node in the list is used and kept until it fails or falls behind
+type EndpointPool struct {
+	MaxHeightLag uint32
+	MaxLatency   time.Duration
+	// endpoints are checked again by Current once their last check is older than Interval
+	Interval time.Duration
+
+	mu        sync.Mutex
+	endpoints []*NeoEndpoint
+	current   int
+	checkedAt time.Time
+}
+
+func NewEndpointPool(urls []string) (*EndpointPool, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("NewEndpointPool, no neo rpc url")
+	}
+	pool := &EndpointPool{
+		MaxHeightLag: DefaultMaxHeightLag,
+		MaxLatency:   DefaultMaxLatency,
+		Interval:     DefaultHealthInterval,
+	}
+	for _, u := range urls {
+		if _, err := url.ParseRequestURI(u); err != nil {
+			return nil, fmt.Errorf("NewEndpointPool, invalid url %s: %v", u, err)
+		}
+		// unchecked nodes are tried in order until the first check
+		pool.endpoints = append(pool.endpoints, &NeoEndpoint{Url: u, Healthy: true})
+	}
+	return pool, nil
+}
+
+// Endpoints returns a copy of the health of each node, in the configured order
+func (this *EndpointPool) Endpoints() []NeoEndpoint {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	res := make([]NeoEndpoint, len(this.endpoints))
+	for i, e := range this.endpoints {
+		res[i] = *e
+	}
+	return res
+}
+
+// Check probes every node concurrently and switches away from the current one if it is unhealthy
+func (this *EndpointPool) Check() {
+	this.mu.Lock()
+	checked := make([]*NeoEndpoint, len(this.endpoints))
+	for i, e := range this.endpoints {
+		checked[i] = &NeoEndpoint{Url: e.Url}
+	}
+	this.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, e := range checked {
+		wg.Add(1)
+		go func(e *NeoEndpoint) {
+			defer wg.Done()
+			this.probe(e)
+		}(e)
+	}
+	wg.Wait()
+	var best uint32
+	for _, e := range checked {
+		if e.Err == "" && e.Height > best {
+			best = e.Height
+		}
+	}
+	for _, e := range checked {
+		switch {
+		case e.Err != "":
+		case e.Latency > this.MaxLatency:
+			e.Err = fmt.Sprintf("latency %s above %s", e.Latency, this.MaxLatency)
+		case e.Height+this.MaxHeightLag < best:
+			e.Err = fmt.Sprintf("height %d lags %d behind %d", e.Height, best-e.Height, best)
+		default:
+			e.Healthy = true
+		}
+	}
+
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.endpoints = checked
+	this.checkedAt = time.Now()
+	if !this.endpoints[this.current].Healthy {
+		this.switchFrom(this.current)
+	}
+}
+
+func (this *EndpointPool) probe(e *NeoEndpoint) {
+	e.CheckedAt = time.Now()
+	req, _ := json.Marshal(rpc.NewRequest("getblockcount", []interface{}{}))
+	cli := &http.Client{Timeout: this.MaxLatency * 2}
+	resp, err := cli.Post(e.Url, "application/json", bytes.NewReader(req))
+	e.Latency = time.Since(e.CheckedAt)
+	if err != nil {
+		e.Err = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	out := rpc.GetBlockCountResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		e.Err = fmt.Sprintf("decode getblockcount response err: %v", err)
+		return
+	}
+	if out.HasError() {
+		e.Err = out.Error.Message
+		return
+	}
+	e.Height = uint32(out.Result)
+}
+
+// Current returns the url of the node to use, after checking the nodes again if they are due
+func (this *EndpointPool) Current() string {
+	this.mu.Lock()
+	due := this.Interval > 0 && time.Since(this.checkedAt) > this.Interval
+	this.mu.Unlock()
+	if due {
+		this.Check()
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	return this.endpoints[this.current].Url
+}
+
+// Failover marks the node at u unhealthy after err and switches to the next healthy one, the
+// nodes are checked again on the next Current if all of them failed
+func (this *EndpointPool) Failover(u string, err error) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	for i, e := range this.endpoints {
+		if e.Url != u {
+			continue
+		}
+		e.Healthy = false
+		e.Err = err.Error()
+		if i == this.current {
+			this.switchFrom(i)
+		}
+	}
+}
+
+// switchFrom moves to the first healthy node other than i, it stays on i if there is none
+func (this *EndpointPool) switchFrom(i int) {
+	for j, e := range this.endpoints {
+		if j != i && e.Healthy {
+			log.Warnf("[EndpointPool], switch neo rpc from %s (%s) to %s", this.endpoints[i].Url, this.endpoints[i].Err, e.Url)
+			this.current = j
+			return
+		}
+	}
+	log.Errorf("[EndpointPool], no healthy neo rpc, keep %s (%s)", this.endpoints[i].Url, this.endpoints[i].Err)
+	this.checkedAt = time.Time{}
+}
+
+// Run checks the nodes every Interval until ctx is done
+func (this *EndpointPool) Run(ctx context.Context) {
+	for {
+		this.Check()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(this.Interval):
+		}
+	}
+}
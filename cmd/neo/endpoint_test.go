@@ -0,0 +1,67 @@
+package neo
+
+import (
+	"errors"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"math/big"
+	"testing"
+)
+
+func Test_EndpointPool(t *testing.T) {
+	lagging, synced := newFakeNeoRpc(t), newFakeNeoRpc(t)
+	synced.Height = lagging.Height + DefaultMaxHeightLag + 1
+	pool, err := NewEndpointPool([]string{lagging.srv.URL, synced.srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Current() != synced.srv.URL {
+		t.Fatalf("expect the synced node, got %s", pool.Current())
+	}
+	health := pool.Endpoints()
+	if health[0].Healthy || health[0].Height != lagging.Height || !health[1].Healthy || health[1].Height != synced.Height {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+
+	// an unreachable node fails over to the next one
+	down := newFakeNeoRpc(t)
+	down.srv.Close()
+	pool, _ = NewEndpointPool([]string{down.srv.URL, synced.srv.URL})
+	pool.Interval = 0
+	acc, _ := wallet.NewAccount()
+	invoker := down.invoker(acc)
+	invoker.Endpoints = pool
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	synced.setStorage(wrapper, []byte{1, 3}, wrapper)
+	res, err := invoker.GetStorage(wrapper, NeoLockProxyKey)
+	if err != nil || res != NeoHash(wrapper).LittleEndian() || invoker.Cli.Endpoint.String() != synced.srv.URL {
+		t.Fatalf("GetStorage on %s: %s, err: %v", invoker.Cli.Endpoint.String(), res, err)
+	}
+	if _, err = NewEndpointPool(nil); err == nil {
+		t.Fatal("an empty pool should be rejected")
+	}
+}
+
+func Test_EndpointPool_StickyConfirm(t *testing.T) {
+	first, second := newFakeNeoRpc(t), newFakeNeoRpc(t)
+	pool, _ := NewEndpointPool([]string{first.srv.URL, second.srv.URL})
+	pool.Interval = 0
+	acc, _ := wallet.NewAccount()
+	invoker := first.invoker(acc)
+	invoker.Endpoints = pool
+	// the node is flagged right after it accepted the tx, which only it knows
+	first.OnSent = func(calls []*ContractCall) {
+		pool.Failover(first.srv.URL, errors.New("flagged by a health check"))
+	}
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	txHash, err := invoker.Lock(wrapper, wrapper, 79, wrapper, big.NewInt(2), big.NewInt(1), big.NewInt(0))
+	if err != nil {
+		t.Fatalf("lock %s should be confirmed on the node it was sent to: %v", txHash, err)
+	}
+	if len(first.Sent) != 1 || len(second.Sent) != 0 {
+		t.Fatalf("sent %d txs to the first node, %d to the second", len(first.Sent), len(second.Sent))
+	}
+	// the next call moves on
+	if _, err = invoker.Paused(wrapper); err != nil || invoker.Cli.Endpoint.String() != second.srv.URL {
+		t.Fatalf("expect the second node after failover, got %s, err: %v", invoker.Cli.Endpoint.String(), err)
+	}
+}
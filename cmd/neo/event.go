@@ -0,0 +1,259 @@
+package neo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"math/big"
+	"strings"
+)
+
+// event names of NeoWrapper.cs
+const (
+	EventPolyWrapperLock      = "PolyWrapperLock"
+	EventPolyWrapperSpeedUp   = "PolyWrapperSpeedUp"
+	EventPaused               = "Paused"
+	EventUnpaused             = "Unpaused"
+	EventOwnershipTransferred = "OwnershipTransferred"
+)
+
+// WrapperEvent is implemented by every event NeoWrapper.cs emits
+type WrapperEvent interface {
+	EventName() string
+}
+
+// EventMeta locates an event, Contract is the poly wrapper which emits it
+type EventMeta struct {
+	TxHash   string
+	Contract helper.UInt160
+}
+
+// PolyWrapperLock(fromAsset, fromAddress, toChainId, toAddress, netAmount, fee, id)
+type PolyWrapperLock struct {
+	EventMeta
+	FromAsset   helper.UInt160
+	FromAddress helper.UInt160
+	ToChainId   uint64
+	ToAddress   []byte
+	Net         *big.Int
+	Fee         *big.Int
+	Id          *big.Int
+}
+
+// PolyWrapperSpeedUp(fromAsset, txHash, fromAddress, fee), LockTxHash is the little endian hash
+// passed to speedUp
+type PolyWrapperSpeedUp struct {
+	EventMeta
+	FromAsset   helper.UInt160
+	LockTxHash  []byte
+	FromAddress helper.UInt160
+	Fee         *big.Int
+}
+
+// Paused(owner)
+type Paused struct {
+	EventMeta
+	Owner helper.UInt160
+}
+
+// Unpaused(owner)
+type Unpaused struct {
+	EventMeta
+	Owner helper.UInt160
+}
+
+// OwnershipTransferred(oldOwner, newOwner)
+type OwnershipTransferred struct {
+	EventMeta
+	OldOwner helper.UInt160
+	NewOwner helper.UInt160
+}
+
+func (this *PolyWrapperLock) EventName() string      { return EventPolyWrapperLock }
+func (this *PolyWrapperSpeedUp) EventName() string   { return EventPolyWrapperSpeedUp }
+func (this *Paused) EventName() string               { return EventPaused }
+func (this *Unpaused) EventName() string             { return EventUnpaused }
+func (this *OwnershipTransferred) EventName() string { return EventOwnershipTransferred }
+
+// ParseWrapperEvents decodes the events of the poly wrapper from a getapplicationlog result,
+// notifications of other contracts, faulted executions and unknown events are skipped
+func ParseWrapperEvents(appLog *models.RpcApplicationLog, neoPolyWrapper []byte) ([]WrapperEvent, error) {
+	wrapper, err := helper.UInt160FromBytes(neoPolyWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("[ParseWrapperEvents], wrapper Uint160FromBytes err: %v", err)
+	}
+	events := make([]WrapperEvent, 0)
+	for _, execution := range appLog.Executions {
+		if strings.Contains(execution.VMState, VMStateFault) {
+			continue
+		}
+		for i, notification := range execution.Notifications {
+			contract, err := helper.UInt160FromString(notification.Contract)
+			if err != nil || contract != wrapper {
+				continue
+			}
+			event, err := ParseWrapperEvent(notification.State.Value)
+			if err != nil {
+				return nil, fmt.Errorf("[ParseWrapperEvents], tx: %s, notification %d err: %v", appLog.TxId, i, err)
+			}
+			if event == nil {
+				continue
+			}
+			meta := EventMeta{TxHash: appLog.TxId, Contract: contract}
+			switch e := event.(type) {
+			case *PolyWrapperLock:
+				e.EventMeta = meta
+			case *PolyWrapperSpeedUp:
+				e.EventMeta = meta
+			case *Paused:
+				e.EventMeta = meta
+			case *Unpaused:
+				e.EventMeta = meta
+			case *OwnershipTransferred:
+				e.EventMeta = meta
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// GetWrapperEvents fetches the application log of txHash and decodes the events of the poly wrapper
+func (this *NeoInvoker) GetWrapperEvents(neoPolyWrapper []byte, txHash string) ([]WrapperEvent, error) {
+	res := this.Cli.GetApplicationLog(txHash)
+	if res.HasError() {
+		return nil, fmt.Errorf("[GetWrapperEvents], GetApplicationLog err: %s", res.Error.Message)
+	}
+	return ParseWrapperEvents(&res.Result, neoPolyWrapper)
+}
+
+// ParseWrapperEvent decodes the state of one notification, it returns nil for
+// states which are not events of the poly wrapper, such as "Fault:" messages
+func ParseWrapperEvent(state []models.RpcContractParameter) (WrapperEvent, error) {
+	if len(state) == 0 {
+		return nil, nil
+	}
+	name, ok := stateString(state[0])
+	if !ok {
+		return nil, nil
+	}
+	args := state[1:]
+	expected := map[string]int{
+		EventPolyWrapperLock:      7,
+		EventPolyWrapperSpeedUp:   4,
+		EventPaused:               1,
+		EventUnpaused:             1,
+		EventOwnershipTransferred: 2,
+	}
+	n, ok := expected[name]
+	if !ok {
+		return nil, nil
+	}
+	if len(args) != n {
+		return nil, fmt.Errorf("event %s expects %d args, got %d", name, n, len(args))
+	}
+	d := &stateDecoder{}
+	switch name {
+	case EventPolyWrapperLock:
+		e := &PolyWrapperLock{
+			FromAsset:   d.hash(args[0]),
+			FromAddress: d.hash(args[1]),
+			ToAddress:   d.bytes(args[3]),
+			Net:         d.integer(args[4]),
+			Fee:         d.integer(args[5]),
+			Id:          d.integer(args[6]),
+		}
+		if toChainId := d.integer(args[2]); toChainId != nil {
+			e.ToChainId = toChainId.Uint64()
+		}
+		return e, d.err(name)
+	case EventPolyWrapperSpeedUp:
+		return &PolyWrapperSpeedUp{
+			FromAsset:   d.hash(args[0]),
+			LockTxHash:  d.bytes(args[1]),
+			FromAddress: d.hash(args[2]),
+			Fee:         d.integer(args[3]),
+		}, d.err(name)
+	case EventPaused:
+		return &Paused{Owner: d.hash(args[0])}, d.err(name)
+	case EventUnpaused:
+		return &Unpaused{Owner: d.hash(args[0])}, d.err(name)
+	default:
+		return &OwnershipTransferred{OldOwner: d.hash(args[0]), NewOwner: d.hash(args[1])}, d.err(name)
+	}
+}
+
+// stateDecoder keeps the first error while decoding the args of an event
+type stateDecoder struct {
+	first error
+}
+
+func (this *stateDecoder) err(name string) error {
+	if this.first != nil {
+		return fmt.Errorf("event %s: %v", name, this.first)
+	}
+	return nil
+}
+
+func (this *stateDecoder) bytes(item models.RpcContractParameter) []byte {
+	bs, err := stateBytes(item)
+	if err != nil && this.first == nil {
+		this.first = err
+	}
+	return bs
+}
+
+// hash decodes a little endian script hash
+func (this *stateDecoder) hash(item models.RpcContractParameter) helper.UInt160 {
+	u, err := helper.UInt160FromBytes(this.bytes(item))
+	if err != nil && this.first == nil {
+		this.first = err
+	}
+	return u
+}
+
+func (this *stateDecoder) integer(item models.RpcContractParameter) *big.Int {
+	n, err := stateInteger(item)
+	if err != nil && this.first == nil {
+		this.first = err
+	}
+	return n
+}
+
+func stateBytes(item models.RpcContractParameter) ([]byte, error) {
+	switch item.Type {
+	case "ByteArray":
+		return hex.DecodeString(item.Value)
+	case "String":
+		return []byte(item.Value), nil
+	}
+	return nil, fmt.Errorf("expect ByteArray, got %s", item.Type)
+}
+
+func stateString(item models.RpcContractParameter) (string, bool) {
+	bs, err := stateBytes(item)
+	if err != nil {
+		return "", false
+	}
+	return string(bs), true
+}
+
+// stateInteger decodes Integer items given in decimal and ByteArray items holding a little endian integer
+func stateInteger(item models.RpcContractParameter) (*big.Int, error) {
+	switch item.Type {
+	case "Integer":
+		n, ok := new(big.Int).SetString(item.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid Integer %s", item.Value)
+		}
+		return n, nil
+	case "ByteArray":
+		bs, err := hex.DecodeString(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		return helper.BigIntFromNeoBytes(bs), nil
+	}
+	return nil, fmt.Errorf("expect Integer, got %s", item.Type)
+}
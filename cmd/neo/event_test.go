@@ -0,0 +1,103 @@
+package neo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"testing"
+)
+
+const testAppLog = `{
+  "txid": "0x9c1e5d6e0d0d6b1aa4b3b4d6f3c6a2b0c7f8e9d0a1b2c3d4e5f60718293a4b5c",
+  "executions": [{
+    "trigger": "Application",
+    "contract": "0x4be8dd6cbd3b8c9e5d3dd8ba0b2e7c8a4f5e0c1d",
+    "vmstate": "HALT",
+    "gas_consumed": "3.2",
+    "stack": [],
+    "notifications": [
+      {"contract": "0x17da3881ab2d050fea414c80b3fa8324d756f60e", "state": {"type": "Array", "value": [
+        {"type": "ByteArray", "value": "7472616e73666572"}]}},
+      {"contract": "0xcd074cd290acc3d73c030784101afbcf40fd86a1", "state": {"type": "Array", "value": [
+        {"type": "ByteArray", "value": "506f6c79577261707065724c6f636b"},
+        {"type": "ByteArray", "value": "0ef656d72483fab3804c41ea0f052dab8138da17"},
+        {"type": "ByteArray", "value": "f726ca68eb68d22943e97a65e6f83213d5312635"},
+        {"type": "ByteArray", "value": "4f"},
+        {"type": "ByteArray", "value": "352631d51332f8e6657ae94329d268eb68ca26f7"},
+        {"type": "Integer", "value": "1000"},
+        {"type": "ByteArray", "value": "e803"},
+        {"type": "ByteArray", "value": ""}]}}
+    ]
+  }]
+}`
+
+func Test_ParseWrapperEvents(t *testing.T) {
+	appLog := &models.RpcApplicationLog{}
+	if err := json.Unmarshal([]byte(testAppLog), appLog); err != nil {
+		t.Fatal(err)
+	}
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	events, err := ParseWrapperEvents(appLog, wrapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expect 1 event, got %d", len(events))
+	}
+	lock, ok := events[0].(*PolyWrapperLock)
+	if !ok {
+		t.Fatalf("expect PolyWrapperLock, got %s", events[0].EventName())
+	}
+	if lock.FromAsset.String() != "17da3881ab2d050fea414c80b3fa8324d756f60e" {
+		t.Fatalf("fromAsset should be rendered big endian, got %s", lock.FromAsset.String())
+	}
+	if helper.ScriptHashToAddress(lock.FromAddress) == "" || lock.ToChainId != 79 {
+		t.Fatalf("unexpected lock: %+v", lock)
+	}
+	if hex.EncodeToString(lock.ToAddress) != "352631d51332f8e6657ae94329d268eb68ca26f7" {
+		t.Fatalf("toAddress should be kept as is, got %x", lock.ToAddress)
+	}
+	if lock.Net.Int64() != 1000 || lock.Fee.Int64() != 1000 || lock.Id.Sign() != 0 {
+		t.Fatalf("unexpected amounts, net: %s, fee: %s, id: %s", lock.Net, lock.Fee, lock.Id)
+	}
+	if lock.TxHash != appLog.TxId || "0x"+lock.Contract.String() != testWrapper {
+		t.Fatalf("unexpected meta: %+v", lock.EventMeta)
+	}
+}
+
+func Test_ParseWrapperEvent(t *testing.T) {
+	name := func(s string) models.RpcContractParameter {
+		return models.RpcContractParameter{Type: "ByteArray", Value: hex.EncodeToString([]byte(s))}
+	}
+	owner := models.RpcContractParameter{Type: "ByteArray", Value: "f726ca68eb68d22943e97a65e6f83213d5312635"}
+	hash := models.RpcContractParameter{Type: "ByteArray", Value: "5c4b3a291807f6e5d4c3b2a1d0e9f8c7b0a2c6f3d6b4b3a41a6b0d0d6e5d1e9c"}
+
+	event, err := ParseWrapperEvent([]models.RpcContractParameter{name(EventPolyWrapperSpeedUp), owner, hash, owner, {Type: "Integer", Value: "7"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	speedUp := event.(*PolyWrapperSpeedUp)
+	if hex.EncodeToString(speedUp.LockTxHash) != hash.Value || speedUp.Fee.Int64() != 7 {
+		t.Fatalf("unexpected speedUp: %+v", speedUp)
+	}
+
+	event, err = ParseWrapperEvent([]models.RpcContractParameter{name(EventOwnershipTransferred), owner, owner})
+	if err != nil || event.EventName() != EventOwnershipTransferred {
+		t.Fatalf("unexpected OwnershipTransferred: %v, %v", event, err)
+	}
+	event, err = ParseWrapperEvent([]models.RpcContractParameter{name(EventPaused), owner})
+	if err != nil || event.(*Paused).Owner.String() != "352631d51332f8e6657ae94329d268eb68ca26f7" {
+		t.Fatalf("unexpected Paused: %v, %v", event, err)
+	}
+
+	if _, err = ParseWrapperEvent([]models.RpcContractParameter{name(EventUnpaused)}); err == nil {
+		t.Fatal("missing args should be rejected")
+	}
+	if _, err = ParseWrapperEvent([]models.RpcContractParameter{name(EventPaused), {Type: "ByteArray", Value: "0102"}}); err == nil {
+		t.Fatal("short script hash should be rejected")
+	}
+	if event, err = ParseWrapperEvent([]models.RpcContractParameter{name(FaultPrefix + FaultPaused)}); event != nil || err != nil {
+		t.Fatalf("fault messages are not events: %v, %v", event, err)
+	}
+}
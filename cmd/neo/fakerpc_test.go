@@ -0,0 +1,243 @@
+package neo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// contractCreateApi is the compressed Neo.Contract.Create syscall as decoded by DecodeInvocationScript
+const contractCreateApi = "f66ca56e"
+
+// fakeNeoRpc is an in-process NEO node. invokescript answers each call of the script with the
+// fixture of its method, a sent tx is packed in the next block and its application log HALTs,
+// or FAULTs like invokescript if it calls a method of Faults
+type fakeNeoRpc struct {
+	t   *testing.T
+	srv *httptest.Server
+	mu  sync.Mutex
+	// Reads is the stack item returned for a method, an empty ByteArray by default
+	Reads map[string]interface{}
+	// Faults is the reason notified by a method which FAULTs
+	Faults map[string]string
+	// Gas is the gas consumed by a call of a method, a script of unlisted methods consumes 0.5
+	Gas map[string]float64
+	// Storage is keyed by the big endian contract hash without 0x and the hex key
+	Storage map[string]string
+	Height  uint32
+	// Unspent is the one GAS unspent of every address, for the fees of large txs
+	Unspent float64
+	// Contracts are the code of the contracts getcontractstate finds by big endian hash, a sent
+	// Neo.Contract.Create adds its contract. They all take and return the types of Main(method, args)
+	Contracts map[string][]byte
+	// OnSent runs for each tx received, with the lock held, to change the fixtures it affects
+	OnSent func(calls []*ContractCall)
+	// Invoked are the scripts run by invokescript, Sent the txs received
+	Invoked [][]byte
+	Sent    []*tx.InvocationTransaction
+	heights map[string]uint32
+}
+
+func newFakeNeoRpc(t *testing.T) *fakeNeoRpc {
+	f := &fakeNeoRpc{
+		t:         t,
+		Reads:     map[string]interface{}{},
+		Faults:    map[string]string{},
+		Gas:       map[string]float64{},
+		Storage:   map[string]string{},
+		Height:    100,
+		Unspent:   1000,
+		Contracts: map[string][]byte{},
+		heights:   map[string]uint32{},
+	}
+	f.srv = httptest.NewServer(f)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+// invoker returns an invoker of the fake signing with acc
+func (this *fakeNeoRpc) invoker(acc *wallet.Account) *NeoInvoker {
+	invoker := newNeoInvoker(this.srv.URL, acc)
+	invoker.ConfirmTimeout = 5 * time.Second
+	return invoker
+}
+
+func (this *fakeNeoRpc) setStorage(contract []byte, key []byte, value []byte) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.Storage[strings.TrimPrefix(NeoHash(contract).BigEndian(), "0x")+hex.EncodeToString(key)] = hex.EncodeToString(value)
+}
+
+// sentCalls decodes the calls of the i-th tx received
+func (this *fakeNeoRpc) sentCalls(i int) []*ContractCall {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if i >= len(this.Sent) {
+		this.t.Fatalf("tx %d not sent, %d txs received", i, len(this.Sent))
+	}
+	calls, err := DecodeInvocationScript(this.Sent[i].Script)
+	if err != nil {
+		this.t.Fatal(err)
+	}
+	return calls
+}
+
+// run returns the vm state, stack and notifications of script
+func (this *fakeNeoRpc) run(script []byte) (string, []interface{}, []interface{}) {
+	calls, err := DecodeInvocationScript(script)
+	if err != nil {
+		return VMStateFault, []interface{}{}, []interface{}{}
+	}
+	stack := make([]interface{}, 0)
+	for _, call := range calls {
+		if call.Syscall != "" {
+			continue
+		}
+		if reason, ok := this.Faults[call.Method]; ok {
+			return VMStateFault, stack, []interface{}{faultNotification(reason)}
+		}
+		item, ok := this.Reads[call.Method]
+		if !ok {
+			item = map[string]interface{}{"type": "ByteArray", "value": ""}
+		}
+		stack = append(stack, item)
+	}
+	return VMStateHalt, stack, []interface{}{}
+}
+
+// gasConsumed returns the gas_consumed of script
+func (this *fakeNeoRpc) gasConsumed(script []byte) string {
+	calls, _ := DecodeInvocationScript(script)
+	gas := 0.0
+	for _, call := range calls {
+		gas += this.Gas[call.Method]
+	}
+	if gas == 0 {
+		gas = 0.5
+	}
+	return strconv.FormatFloat(gas, 'f', -1, 64)
+}
+
+func (this *fakeNeoRpc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		Method string        `json:"method"`
+		Params []interface{} `json:"params"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	this.mu.Lock()
+	result, err := this.handle(req.Method, req.Params)
+	this.mu.Unlock()
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+	if err != nil {
+		resp["error"] = map[string]interface{}{"code": -100, "message": err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (this *fakeNeoRpc) handle(method string, params []interface{}) (interface{}, error) {
+	param := func(i int) string {
+		if i >= len(params) {
+			return ""
+		}
+		s, _ := params[i].(string)
+		return s
+	}
+	switch method {
+	case "invokescript":
+		script := helper.HexToBytes(param(0))
+		this.Invoked = append(this.Invoked, script)
+		state, stack, notifications := this.run(script)
+		return map[string]interface{}{"script": param(0), "state": state, "gas_consumed": this.gasConsumed(script), "stack": stack, "notifications": notifications}, nil
+	case "sendrawtransaction":
+		itx, err := (&tx.InvocationTransaction{Transaction: tx.NewTransaction()}).FromHexString(param(0))
+		if err != nil {
+			return nil, fmt.Errorf("invalid raw tx: %v", err)
+		}
+		if len(itx.Witnesses) == 0 || !tx.VerifySignatureWitness(itx.UnsignedRawTransaction(), itx.Witnesses[0]) {
+			return nil, fmt.Errorf("invalid witness of tx %s", itx.HashString())
+		}
+		this.Sent = append(this.Sent, itx)
+		calls, err := DecodeInvocationScript(itx.Script)
+		if err == nil {
+			for _, call := range calls {
+				if call.Syscall == contractCreateApi && len(call.Args) > 0 {
+					hash, _ := ContractHash(call.Args[0].Bytes)
+					this.Contracts[hash.BigEndian()] = call.Args[0].Bytes
+				}
+			}
+		}
+		if err == nil && this.OnSent != nil {
+			this.OnSent(calls)
+		}
+		this.heights[strings.TrimPrefix(itx.HashString(), "0x")] = this.Height
+		this.Height++
+		return true, nil
+	case "gettransactionheight":
+		h, ok := this.heights[strings.TrimPrefix(param(0), "0x")]
+		if !ok {
+			return nil, fmt.Errorf("Unknown transaction")
+		}
+		return h, nil
+	case "getblockcount":
+		return this.Height, nil
+	case "getrawmempool":
+		return []string{}, nil
+	case "getapplicationlog":
+		for _, itx := range this.Sent {
+			if !sameTxHash(itx.HashString(), param(0)) {
+				continue
+			}
+			state, stack, notifications := this.run(itx.Script)
+			return map[string]interface{}{
+				"txid": param(0),
+				"executions": []interface{}{
+					map[string]interface{}{"trigger": "Application", "vmstate": state, "gas_consumed": this.gasConsumed(itx.Script), "stack": stack, "notifications": notifications},
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("Unknown transaction")
+	case "getrawtransaction":
+		for _, itx := range this.Sent {
+			if sameTxHash(itx.HashString(), param(0)) {
+				return map[string]interface{}{"txid": itx.HashString(), "type": "InvocationTransaction", "script": helper.BytesToHex(itx.Script)}, nil
+			}
+		}
+		return nil, fmt.Errorf("Unknown transaction")
+	case "getunspents":
+		gas := map[string]interface{}{
+			"asset_hash": tx.GasToken.String(),
+			"asset":      "GAS",
+			"amount":     this.Unspent,
+			"unspent":    []interface{}{map[string]interface{}{"txid": strings.Repeat("ab", 32), "n": 0, "value": this.Unspent}},
+		}
+		return map[string]interface{}{"address": param(0), "balance": []interface{}{gas}}, nil
+	case "getcontractstate":
+		code, ok := this.Contracts[param(0)]
+		if !ok {
+			return nil, fmt.Errorf("Unknown contract")
+		}
+		return map[string]interface{}{"hash": param(0), "script": helper.BytesToHex(code), "parameters": []string{"String", "Array"}, "returntype": "ByteArray"}, nil
+	case "getstorage":
+		value, ok := this.Storage[param(0)+param(1)]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("method %s not found", method)
+}
@@ -0,0 +1,94 @@
+package neo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"strings"
+)
+
+// NeoWrapper.cs reports why it faults through Runtime.Notify(FaultPrefix + reason)
+const FaultPrefix = "Fault:"
+
+// fault reasons of NeoWrapper.cs
+const (
+	FaultNotOwner          = "!owner"
+	FaultNotFeeCollector   = "!feeCollector"
+	FaultNotFromAddress    = "!fromAddress"
+	FaultInvalidToChainId  = "!toChainId"
+	FaultAmountLessThanFee = "amount less than fee"
+	FaultPaused            = "paused"
+	FaultInvalidLength     = "len != 20"
+	FaultPullFail          = "_pull fail"
+	FaultLockFail          = "lock fail"
+	FaultTransferFail      = "trasnfer fail"
+)
+
+// ScriptFaultError is a FAULT of invokescript
+type ScriptFaultError struct {
+	GasConsumed string
+}
+
+func (this *ScriptFaultError) Error() string {
+	return fmt.Sprintf("script ends in %s, gas consumed: %s", VMStateFault, this.GasConsumed)
+}
+
+// ContractFaultError is a FAULT explained by a "Fault:" notification of the contract. Method lists
+// the methods called by the script, TxHash is empty if the script was only invoked
+type ContractFaultError struct {
+	Method   string
+	Reason   string
+	Contract string
+	TxHash   string
+	cause    error
+}
+
+func (this *ContractFaultError) Error() string {
+	if this.TxHash != "" {
+		return fmt.Sprintf("contract %s faults in %s, reason: %s, txHash: %s", this.Contract, this.Method, this.Reason, this.TxHash)
+	}
+	return fmt.Sprintf("contract %s faults in %s, reason: %s", this.Contract, this.Method, this.Reason)
+}
+
+// Unwrap returns the *ScriptFaultError or *TxFaultError behind the fault
+func (this *ContractFaultError) Unwrap() error {
+	return this.cause
+}
+
+// IsContractFault reports whether err is a contract fault with the given reason
+func IsContractFault(err error, reason string) bool {
+	var faultErr *ContractFaultError
+	return errors.As(err, &faultErr) && faultErr.Reason == reason
+}
+
+// FaultReason returns the reason of the first "Fault:" notification and the contract sending it
+func FaultReason(notifications []models.RpcNotification) (reason string, contract string, ok bool) {
+	for _, notification := range notifications {
+		for _, item := range notification.State.Value {
+			msg, ok := stateString(item)
+			if !ok {
+				continue
+			}
+			if strings.HasPrefix(msg, FaultPrefix) {
+				return strings.TrimPrefix(msg, FaultPrefix), notification.Contract, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// newContractFaultError returns a *ContractFaultError wrapping cause if the notifications carry a
+// fault reason, otherwise cause itself
+func newContractFaultError(method, txHash string, notifications []models.RpcNotification, cause error) error {
+	reason, contract, ok := FaultReason(notifications)
+	if !ok {
+		return cause
+	}
+	return &ContractFaultError{
+		Method:   method,
+		Reason:   reason,
+		Contract: contract,
+		TxHash:   txHash,
+		cause:    cause,
+	}
+}
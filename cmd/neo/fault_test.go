@@ -0,0 +1,128 @@
+package neo
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const testWrapper = "0xcd074cd290acc3d73c030784101afbcf40fd86a1"
+
+func faultNotification(reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"contract": testWrapper,
+		"state": map[string]interface{}{
+			"type": "Array",
+			"value": []interface{}{
+				map[string]interface{}{"type": "ByteArray", "value": hex.EncodeToString([]byte(FaultPrefix + reason))},
+			},
+		},
+	}
+}
+
+func lockScript() []byte {
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	sb := sc.NewScriptBuilder()
+	sb.MakeInvocationScript(wrapper, "lock", []sc.ContractParameter{
+		{Type: sc.ByteArray, Value: wrapper},
+		{Type: sc.Integer, Value: *big.NewInt(79)},
+	})
+	return sb.ToArray()
+}
+
+func Test_InvokeScript_ContractFault(t *testing.T) {
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"invokescript": map[string]interface{}{
+			"state":         "FAULT, BREAK",
+			"gas_consumed":  "0.5",
+			"stack":         []interface{}{},
+			"notifications": []interface{}{faultNotification(FaultPaused)},
+		},
+	})
+	_, err := invoker.InvokeScript(lockScript(), helper.UInt160{})
+	var faultErr *ContractFaultError
+	if !errors.As(err, &faultErr) {
+		t.Fatalf("expect ContractFaultError, got: %v", err)
+	}
+	if faultErr.Method != "lock" || faultErr.Reason != FaultPaused || faultErr.Contract != testWrapper {
+		t.Fatalf("unexpected fault: %+v", faultErr)
+	}
+	if !IsContractFault(err, FaultPaused) || IsContractFault(err, FaultNotOwner) {
+		t.Fatal("IsContractFault mismatch")
+	}
+	var scriptErr *ScriptFaultError
+	if !errors.As(err, &scriptErr) || scriptErr.GasConsumed != "0.5" {
+		t.Fatalf("expect wrapped ScriptFaultError, got: %v", err)
+	}
+}
+
+func Test_InvokeScript_FaultWithoutReason(t *testing.T) {
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"invokescript": map[string]interface{}{"state": "FAULT", "gas_consumed": "0.1", "stack": []interface{}{}},
+	})
+	_, err := invoker.InvokeScript(lockScript(), helper.UInt160{})
+	var faultErr *ContractFaultError
+	if errors.As(err, &faultErr) {
+		t.Fatalf("fault without notification should not be a contract fault: %v", err)
+	}
+	var scriptErr *ScriptFaultError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("expect ScriptFaultError, got: %v", err)
+	}
+}
+
+func Test_ConfirmTx_ContractFault(t *testing.T) {
+	appLog := applicationLog("FAULT")
+	appLog["executions"].([]interface{})[0].(map[string]interface{})["notifications"] = []interface{}{faultNotification(FaultAmountLessThanFee)}
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"gettransactionheight": 10,
+		"getblockcount":        11,
+		"getapplicationlog":    appLog,
+		"getrawtransaction":    map[string]interface{}{"txid": testTxHash, "script": hex.EncodeToString(lockScript())},
+	})
+	_, err := invoker.ConfirmTx(context.Background(), testTxHash, time.Second, 1)
+	if !IsContractFault(err, FaultAmountLessThanFee) {
+		t.Fatalf("expect amount less than fee fault, got: %v", err)
+	}
+	var faultErr *ContractFaultError
+	errors.As(err, &faultErr)
+	if faultErr.Method != "lock" || faultErr.TxHash != testTxHash {
+		t.Fatalf("unexpected fault: %+v", faultErr)
+	}
+	var txErr *TxFaultError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expect wrapped TxFaultError, got: %v", err)
+	}
+}
+
+func Test_DecodeInvocationScript(t *testing.T) {
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	sb := sc.NewScriptBuilder()
+	sb.MakeInvocationScript(wrapper, "pause", nil)
+	sb.MakeInvocationScript(wrapper, "setLockProxy", []sc.ContractParameter{{Type: sc.ByteArray, Value: wrapper}})
+	sb.MakeInvocationScript(wrapper, "lock", []sc.ContractParameter{
+		{Type: sc.String, Value: string(make([]byte, 300))},
+		{Type: sc.Integer, Value: *big.NewInt(1000000)},
+	})
+	calls, err := DecodeInvocationScript(sb.ToArray())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 || calls[0].Method != "pause" || len(calls[0].Args) != 0 {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+	if calls[1].Method != "setLockProxy" || len(calls[1].Args) != 1 || hex.EncodeToString(calls[1].Args[0].Bytes) != hex.EncodeToString(wrapper) {
+		t.Fatalf("unexpected setLockProxy call: %+v", calls[1])
+	}
+	if calls[2].Method != "lock" || len(calls[2].Args[0].Bytes) != 300 || helper.BigIntFromNeoBytes(calls[2].Args[1].Bytes).Int64() != 1000000 {
+		t.Fatalf("unexpected lock call: %+v", calls[2])
+	}
+	if hex.EncodeToString(calls[2].ScriptHash) != hex.EncodeToString(wrapper) {
+		t.Fatalf("unexpected script hash: %x", calls[2].ScriptHash)
+	}
+}
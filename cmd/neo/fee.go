@@ -0,0 +1,166 @@
+package neo
+
+import (
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/skyinglyh1/poly_wrapper/log"
+)
+
+const (
+	// every invocation gets FreeGas before system fee is charged
+	FreeGas = 10
+	// txs larger than FreeTxSize must pay a network fee of LowPriorityThreshold plus FeePerByte for each byte
+	FreeTxSize = 1024
+
+	FeePriorityNormal = "normal"
+	FeePriorityHigh   = "high"
+
+	// size of a single signature witness: varlen + PUSHBYTES64 and the signature, varlen + verification
+	// script. The count of witnesses is in the size of the unsigned tx already
+	signatureWitnessSize = 1 + 65 + 1 + 35
+)
+
+var (
+	FeePerByte           = helper.Fixed8FromFloat64(0.00001)
+	LowPriorityThreshold = helper.Fixed8FromFloat64(0.001)
+)
+
+// FeePolicy decides the network fee attached to NEO invocation transactions
+type FeePolicy struct {
+	// Priority is FeePriorityNormal to pay only what the node requires, or FeePriorityHigh to pay
+	// at least LowPriorityThreshold so the tx is not queued behind free txs
+	Priority string
+	// ExtraNetFee is added on top of the network fee required by Priority
+	ExtraNetFee helper.Fixed8
+}
+
+// FeeEstimate is the fee of an invocation script derived from invokescript
+type FeeEstimate struct {
+	GasConsumed helper.Fixed8
+	SystemFee   helper.Fixed8
+	NetworkFee  helper.Fixed8
+	Size        int
+}
+
+func (this *FeeEstimate) Total() helper.Fixed8 {
+	return this.SystemFee.Add(this.NetworkFee)
+}
+
+func DefaultFeePolicy() *FeePolicy {
+	return &FeePolicy{Priority: FeePriorityNormal, ExtraNetFee: helper.Zero}
+}
+
+func NewFeePolicy(priority string, extraNetFee float64) (*FeePolicy, error) {
+	switch priority {
+	case "":
+		priority = FeePriorityNormal
+	case FeePriorityNormal, FeePriorityHigh:
+	default:
+		return nil, fmt.Errorf("unknown fee priority: %s", priority)
+	}
+	if extraNetFee < 0 {
+		return nil, fmt.Errorf("extra network fee should not be negative: %f", extraNetFee)
+	}
+	return &FeePolicy{Priority: priority, ExtraNetFee: helper.Fixed8FromFloat64(extraNetFee)}, nil
+}
+
+// NetworkFee returns the network fee for a tx of size bytes
+func (this *FeePolicy) NetworkFee(size int) helper.Fixed8 {
+	fee := helper.Zero
+	if size > FreeTxSize {
+		fee = LowPriorityThreshold.Add(helper.NewFixed8(FeePerByte.Value * int64(size)))
+	}
+	if this.Priority == FeePriorityHigh && fee.LessThan(LowPriorityThreshold) {
+		fee = LowPriorityThreshold
+	}
+	return fee.Add(this.ExtraNetFee)
+}
+
+// SystemFee returns the system fee for gasConsumed, the free gas is deducted and the rest is rounded up
+func SystemFee(gasConsumed helper.Fixed8) helper.Fixed8 {
+	gas := gasConsumed.Sub(helper.Fixed8FromInt64(FreeGas))
+	if !gas.GreaterThan(helper.Zero) {
+		return helper.Zero
+	}
+	return gas.Ceiling()
+}
+
+// EstimateGas runs the script through invokescript with from as witness and returns the gas consumed
+func (this *NeoInvoker) EstimateGas(script []byte, from helper.UInt160) (helper.Fixed8, error) {
+	response, err := this.InvokeScript(script, from)
+	if err != nil {
+		return helper.Zero, fmt.Errorf("[EstimateGas], InvokeScript err: %w", err)
+	}
+	gasConsumed, err := helper.Fixed8FromString(response.GasConsumed)
+	if err != nil {
+		return helper.Zero, fmt.Errorf("[EstimateGas], gas_consumed: %s, Fixed8FromString err: %v", response.GasConsumed, err)
+	}
+	return gasConsumed, nil
+}
+
+// MakeInvocationTx builds an unsigned InvocationTransaction paid by from, the system fee comes from
+// invokescript and the network fee from the tx size and this.FeePolicy. It fails if from does not
+// hold enough GAS to pay both
+func (this *NeoInvoker) MakeInvocationTx(script []byte, from helper.UInt160) (*tx.InvocationTransaction, *FeeEstimate, error) {
+	return this.makeInvocationTx(script, from, signatureWitnessSize)
+}
+
+// makeInvocationTx is MakeInvocationTx for a sender whose witness takes witnessSize bytes
+func (this *NeoInvoker) makeInvocationTx(script []byte, from helper.UInt160, witnessSize int) (*tx.InvocationTransaction, *FeeEstimate, error) {
+	gasConsumed, err := this.EstimateGas(script, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	policy := this.FeePolicy
+	if policy == nil {
+		policy = DefaultFeePolicy()
+	}
+	estimate := &FeeEstimate{
+		GasConsumed: gasConsumed,
+		SystemFee:   SystemFee(gasConsumed),
+	}
+	tb := tx.NewTransactionBuilder(this.Cli.Endpoint.String())
+
+	itx := tx.NewInvocationTransaction(script)
+	itx.Gas = estimate.SystemFee
+	// the sender signs through a script attribute, the witness is not added yet
+	itx.AddScriptHashToAttribute(from)
+	// inputs and change output change the size, so repeat until the network fee covers the final size
+	estimate.NetworkFee = policy.NetworkFee(itx.Size() + witnessSize)
+	for i := 0; ; i++ {
+		if i == 3 {
+			return nil, nil, fmt.Errorf("[MakeInvocationTx], network fee does not converge, size: %d", estimate.Size)
+		}
+		itx.Inputs = []*tx.CoinReference{}
+		itx.Outputs = []*tx.TransactionOutput{}
+		fee := estimate.Total()
+		if fee.GreaterThan(helper.Zero) {
+			_, balance, err := tb.GetBalance(from, tx.GasToken)
+			if err != nil {
+				return nil, nil, fmt.Errorf("[MakeInvocationTx], get GAS balance of %s err: %v", helper.ScriptHashToAddress(from), err)
+			}
+			if balance.LessThan(fee) {
+				return nil, nil, fmt.Errorf("[MakeInvocationTx], %s has %s GAS, needs %s (system fee %s, network fee %s)",
+					helper.ScriptHashToAddress(from), balance.String(), fee.String(), estimate.SystemFee.String(), estimate.NetworkFee.String())
+			}
+			inputs, totalPayGas, err := tb.GetTransactionInputs(from, tx.GasToken, fee)
+			if err != nil {
+				return nil, nil, fmt.Errorf("[MakeInvocationTx], GetTransactionInputs err: %v", err)
+			}
+			itx.Inputs = inputs
+			if totalPayGas.GreaterThan(fee) {
+				itx.Outputs = append(itx.Outputs, tx.NewTransactionOutput(tx.GasToken, totalPayGas.Sub(fee), from))
+			}
+		}
+		estimate.Size = itx.Size() + witnessSize
+		required := policy.NetworkFee(estimate.Size)
+		if !required.GreaterThan(estimate.NetworkFee) {
+			break
+		}
+		estimate.NetworkFee = required
+	}
+	log.Debugf("[MakeInvocationTx], gas consumed: %s, system fee: %s, network fee: %s, size: %d",
+		estimate.GasConsumed.String(), estimate.SystemFee.String(), estimate.NetworkFee.String(), estimate.Size)
+	return itx, estimate, nil
+}
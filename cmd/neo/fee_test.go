@@ -0,0 +1,71 @@
+package neo
+
+import (
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"testing"
+)
+
+func Test_FeePolicy_NetworkFee(t *testing.T) {
+	normal, err := NewFeePolicy("", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := NewFeePolicy(FeePriorityHigh, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewFeePolicy("urgent", 0); err == nil {
+		t.Fatal("unknown priority should be rejected")
+	}
+
+	cases := []struct {
+		policy *FeePolicy
+		size   int
+		fee    helper.Fixed8
+	}{
+		{normal, 300, helper.Zero},
+		{normal, FreeTxSize, helper.Zero},
+		{normal, 2000, helper.Fixed8FromFloat64(0.021)},
+		{high, 300, helper.Fixed8FromFloat64(0.501)},
+		{high, 2000, helper.Fixed8FromFloat64(0.521)},
+	}
+	for i, c := range cases {
+		if fee := c.policy.NetworkFee(c.size); !fee.Equal(c.fee) {
+			t.Fatalf("case %d, network fee for %d bytes: %s, expected: %s", i, c.size, fee.String(), c.fee.String())
+		}
+	}
+}
+
+func Test_SystemFee(t *testing.T) {
+	cases := map[string]string{
+		"0.123":   "0",
+		"10":      "0",
+		"10.0001": "1",
+		"12.5":    "3",
+	}
+	for consumed, expected := range cases {
+		gas, _ := helper.Fixed8FromString(consumed)
+		if fee := SystemFee(gas); fee.String() != expected {
+			t.Fatalf("gas consumed: %s, system fee: %s, expected: %s", consumed, fee.String(), expected)
+		}
+	}
+}
+
+func Test_SignatureWitnessSize(t *testing.T) {
+	acc, _ := wallet.NewAccount()
+	from, _ := helper.AddressToScriptHash(acc.Address)
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	script, _ := OwnerScript(wrapper, "pause", nil)
+	itx := tx.NewInvocationTransaction(script)
+	itx.AddScriptHashToAttribute(from)
+	unsigned := itx.Size()
+	if err := signer.SignNeoTx(itx, signer.NewNeoKeySigner(acc.KeyPair)); err != nil {
+		t.Fatal(err)
+	}
+	if itx.Size() != unsigned+signatureWitnessSize {
+		t.Fatalf("signed tx of %d bytes, expect %d + %d", itx.Size(), unsigned, signatureWitnessSize)
+	}
+}
@@ -0,0 +1,83 @@
+package neo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/joeqian10/neo-gogogo/helper"
+)
+
+// NeoHash is a script hash kept in the little endian byte order the contracts store and receive.
+// Hashes bound for other chains, such as the proxy and asset hashes of a lock proxy, are kept
+// as stored too, so LittleEndian() is the raw value and BigEndian() only makes sense on NEO
+type NeoHash []byte
+
+// ParseNeoHash accepts the formats of ParseNeoAddr: 0x big endian hex, base58 address or little endian hex
+func ParseNeoHash(s string) (NeoHash, error) {
+	b, err := ParseNeoAddr(s)
+	if err != nil {
+		return nil, err
+	}
+	return NeoHash(b), nil
+}
+
+func (this NeoHash) Bytes() []byte {
+	return []byte(this)
+}
+
+// LittleEndian is the hex of the stored bytes, without 0x
+func (this NeoHash) LittleEndian() string {
+	return hex.EncodeToString(this)
+}
+
+// BigEndian is the 0x prefixed hex shown by NEO explorers and wallets
+func (this NeoHash) BigEndian() string {
+	return "0x" + hex.EncodeToString(helper.ReverseBytes(this))
+}
+
+// Address is the base58 NEO address, empty if the hash is not 20 bytes
+func (this NeoHash) Address() string {
+	u, err := this.UInt160()
+	if err != nil {
+		return ""
+	}
+	return helper.ScriptHashToAddress(u)
+}
+
+func (this NeoHash) UInt160() (helper.UInt160, error) {
+	return helper.UInt160FromBytes(this)
+}
+
+// IsZero is true for an empty or all zero hash, as returned for an unset value
+func (this NeoHash) IsZero() bool {
+	for _, b := range this {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (this NeoHash) Equal(other []byte) bool {
+	return string(this) == string(other)
+}
+
+func (this NeoHash) String() string {
+	return this.BigEndian()
+}
+
+func (this NeoHash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.BigEndian())
+}
+
+func (this *NeoHash) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	h, err := ParseNeoHash(s)
+	if err != nil {
+		return err
+	}
+	*this = h
+	return nil
+}
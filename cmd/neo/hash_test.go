@@ -0,0 +1,35 @@
+package neo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_NeoHash(t *testing.T) {
+	h, err := ParseNeoHash(testWrapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.BigEndian() != testWrapper || h.String() != testWrapper {
+		t.Fatalf("unexpected big endian: %s", h.BigEndian())
+	}
+	if h.LittleEndian() != "a186fd40cffb1a108407033cd7c3ac90d24c07cd" {
+		t.Fatalf("unexpected little endian: %s", h.LittleEndian())
+	}
+	addr, err := ParseNeoHash(h.Address())
+	if err != nil || !addr.Equal(h) {
+		t.Fatalf("address %s should parse back to the hash, err: %v", h.Address(), err)
+	}
+	if NeoHash([]byte{1, 2}).Address() != "" || !NeoHash(nil).IsZero() || h.IsZero() {
+		t.Fatal("unexpected short or zero hash handling")
+	}
+
+	data, err := json.Marshal(struct{ Owner NeoHash }{h})
+	if err != nil || string(data) != `{"Owner":"`+testWrapper+`"}` {
+		t.Fatalf("unexpected json: %s, %v", data, err)
+	}
+	out := struct{ Owner NeoHash }{}
+	if err = json.Unmarshal(data, &out); err != nil || !out.Owner.Equal(h) {
+		t.Fatalf("json round trip failed: %x, %v", out.Owner, err)
+	}
+}
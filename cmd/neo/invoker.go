@@ -1,34 +1,105 @@
 package neo
 
 import (
-	"encoding/hex"
 	"fmt"
-	"github.com/joeqian10/neo-gogogo/helper"
 	"github.com/joeqian10/neo-gogogo/rpc"
 	"github.com/joeqian10/neo-gogogo/sc"
 	"github.com/joeqian10/neo-gogogo/wallet"
 	"github.com/ontio/ontology/common"
-	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"github.com/skyinglyh1/poly_wrapper/signer"
 	"math/big"
-	"strings"
 	"time"
 )
 
 type NeoInvoker struct {
 	Cli *rpc.RpcClient
-	Acc *wallet.Account
+	// Endpoints, if set, picks the node of Cli and fails over between nodes, see useEndpoint
+	Endpoints *EndpointPool
+	// Acc is the wallet account the invoker was made with, nil with a bare signer. The write
+	// methods sign with Signer
+	Acc       *wallet.Account
+	Signer    signer.NeoSigner
+	FeePolicy *FeePolicy
+	// write methods wait at most ConfirmTimeout for their tx to get Confirmations blocks
+	ConfirmTimeout time.Duration
+	Confirmations  uint32
 }
 
 func NewNeoInvoker(url, walletPath, walletPwd string) (invoker *NeoInvoker, err error) {
-	invoker = &NeoInvoker{}
-	invoker.Cli = rpc.NewClient(url)
-	invoker.Acc = GetAccountByPassword(walletPath, walletPwd)
-	if invoker.Acc == nil {
-		return nil, fmt.Errorf("NewNeoInvoker GetAccountByPassword error")
+	return NewNeoInvokerWithAccount(url, walletPath, walletPwd, "")
+}
+
+// NewNeoInvokerWithAccount signs with the wallet account whose address or label is account
+func NewNeoInvokerWithAccount(url, walletPath, walletPwd, account string) (*NeoInvoker, error) {
+	acc, err := GetAccount(walletPath, walletPwd, account)
+	if err != nil {
+		return nil, fmt.Errorf("NewNeoInvoker, %v", err)
+	}
+	return newNeoInvoker(url, acc), nil
+}
+
+func NewNeoInvokerFromWIF(url, wif string) (*NeoInvoker, error) {
+	acc, err := GetAccountByWIF(wif)
+	if err != nil {
+		return nil, fmt.Errorf("NewNeoInvokerFromWIF, %v", err)
+	}
+	return newNeoInvoker(url, acc), nil
+}
+
+// NewNeoInvokerWithSigner signs with s, which may keep its key out of this process
+func NewNeoInvokerWithSigner(url string, s signer.NeoSigner) *NeoInvoker {
+	invoker := newNeoInvoker(url, nil)
+	invoker.Signer = s
+	return invoker
+}
+
+// NewNeoInvokerFromConfig signs with NeoWif if set, with NeoAccount of the remote NeoSignerUrl if
+// set, otherwise with NeoAccount of NeoWallet, and pays fees with NeoFeePriority and NeoExtraNetFee.
+// It fails over between NeoUrls if more than one is set
+func NewNeoInvokerFromConfig(cfg *config.TestConfig) (*NeoInvoker, error) {
+	policy, err := NewFeePolicy(cfg.NeoFeePriority, cfg.NeoExtraNetFee)
+	if err != nil {
+		return nil, fmt.Errorf("NewNeoInvokerFromConfig, %v", err)
+	}
+	var invoker *NeoInvoker
+	if cfg.NeoWif != "" {
+		invoker, err = NewNeoInvokerFromWIF(cfg.NeoRpcUrls()[0], cfg.NeoWif)
+	} else if cfg.NeoSignerUrl != "" {
+		var s *signer.RemoteNeoSigner
+		if s, err = signer.NewRemoteNeoSigner(cfg.NeoSignerUrl, cfg.NeoAccount); err != nil {
+			return nil, fmt.Errorf("NewNeoInvokerFromConfig, %v", err)
+		}
+		invoker = NewNeoInvokerWithSigner(cfg.NeoRpcUrls()[0], s)
+	} else {
+		invoker, err = NewNeoInvokerWithAccount(cfg.NeoRpcUrls()[0], cfg.NeoWallet, cfg.NeoWalletPwd, cfg.NeoAccount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	invoker.FeePolicy = policy
+	if urls := cfg.NeoRpcUrls(); len(urls) > 1 {
+		if invoker.Endpoints, err = NewEndpointPool(urls); err != nil {
+			return nil, fmt.Errorf("NewNeoInvokerFromConfig, %v", err)
+		}
 	}
 	return invoker, nil
 }
 
+func newNeoInvoker(url string, acc *wallet.Account) *NeoInvoker {
+	invoker := &NeoInvoker{
+		Cli:            rpc.NewClient(url),
+		Acc:            acc,
+		FeePolicy:      DefaultFeePolicy(),
+		ConfirmTimeout: DefaultConfirmTimeout,
+		Confirmations:  DefaultConfirmations,
+	}
+	if acc != nil {
+		invoker.Signer = signer.NewNeoKeySigner(acc.KeyPair)
+	}
+	return invoker
+}
+
 func (this *NeoInvoker) GetAssetBalances(neoLockProxy []byte, fromAssetHashs [][]byte) ([]*big.Int, error) {
 	scriptBuilder := sc.NewScriptBuilder()
 
@@ -42,49 +113,26 @@ func (this *NeoInvoker) GetAssetBalances(neoLockProxy []byte, fromAssetHashs [][
 	}
 	script := scriptBuilder.ToArray()
 
-	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return nil, fmt.Errorf("[GetAssetBalances], InvokeScript err: %v", response.Error)
+	items, err := this.invokeRead(script, len(fromAssetHashs))
+	if err != nil {
+		return nil, fmt.Errorf("[GetAssetBalances], invokeRead err: %w", err)
 	}
 	res := make([]*big.Int, len(fromAssetHashs))
-	for i, stack := range response.Result.Stack {
-		stack.Convert()
-
-		if stack.Type == "ByteArray" {
-			hexNumBs, _ := hex.DecodeString(stack.Value.(string))
-			res[i] = common.BigIntFromNeoBytes(hexNumBs)
+	for i, item := range items {
+		if res[i], err = item.Integer(); err != nil {
+			return nil, fmt.Errorf("[GetAssetBalances], asset %x: %v", fromAssetHashs[i], err)
 		}
 	}
 	return res, nil
 }
 
-func (this *NeoInvoker) GetStorage(neoLockProxy []byte, key string) (string, error) {
-	addr, _ := common.AddressParseFromBytes(neoLockProxy)
+// GetStorage returns the hex value of key in the storage of contract, empty if unset
+func (this *NeoInvoker) GetStorage(contract []byte, key string) (string, error) {
+	addr, _ := common.AddressParseFromBytes(contract)
 
-	resp := this.Cli.GetStorage(addr.ToHexString(), key)
-	if resp.HasError() {
-		return "", fmt.Errorf("resp.Error.Message: %s", resp.Error.Message)
-	}
-	return resp.Result, nil
-}
-
-func WaitNeoTx(cli *rpc.RpcClient, hash helper.UInt256) {
-	tick := time.NewTicker(100 * time.Millisecond)
-	for range tick.C {
-		res := cli.GetTransactionHeight(hash.String())
-		if res.HasError() {
-			if strings.Contains(res.Error.Message, "Unknown") {
-				continue
-			}
-			log.Errorf("failed to get neo tx: %s", res.Error.Message)
-			continue
-		}
-		if res.Result <= 0 {
-			continue
-		}
-		log.Infof("capture neo tx %s", hash.String())
-		break
+	var res string
+	if err := this.call("getstorage", []interface{}{addr.ToHexString(), key}, &res); err != nil {
+		return "", fmt.Errorf("[GetStorage], %v", err)
 	}
+	return res, nil
 }
@@ -1,156 +1,144 @@
 package neo
 
 import (
-	"encoding/hex"
 	"fmt"
 	"github.com/joeqian10/neo-gogogo/helper"
 	"github.com/joeqian10/neo-gogogo/sc"
-	"github.com/joeqian10/neo-gogogo/tx"
-	"github.com/polynetwork/poly/common"
 	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
 	"math/big"
 )
 
 func (this *NeoInvoker) BindProxyHash(neoLockProxy []byte, toChainId uint64, toProxyHash []byte) error {
-	from, err := ParseNeoAddr(this.Acc.Address)
+	from, err := ParseNeoAddr(this.Signer.Address())
 	if err != nil {
-		return fmt.Errorf("[BindProxyHash], ParseNeoAddr acct: %s,  err: %v", this.Acc.Address, err)
+		return fmt.Errorf("[BindProxyHash], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
 	}
 	fromUint160, err := helper.UInt160FromBytes(from)
 	if err != nil {
 		return fmt.Errorf("[BindProxyHash], Uint160FromBytes err: %v", err)
 	}
-	tci := big.NewInt(int64(toChainId))
-	toChainIdValue := sc.ContractParameter{
-		Type:  sc.Integer,
-		Value: *tci,
-	}
-	toProxyHashValue := sc.ContractParameter{
-		Type:  sc.ByteArray,
-		Value: toProxyHash,
-	}
-	// build script
-	scriptBuilder := sc.NewScriptBuilder()
-	args := []sc.ContractParameter{toChainIdValue, toProxyHashValue}
-	scriptBuilder.MakeInvocationScript(neoLockProxy, "bindProxyHash", args)
-	script := scriptBuilder.ToArray()
+	script := BindProxyHashScript(neoLockProxy, toChainId, toProxyHash)
 
 	// create an InvocationTransaction
-	tb := tx.NewTransactionBuilder(this.Cli.Endpoint.String())
-
-	sysFee := helper.Fixed8FromFloat64(0)
-	netFee := helper.Fixed8FromFloat64(0)
-
-	itx, err := tb.MakeInvocationTransaction(script, fromUint160, nil, fromUint160, sysFee, netFee)
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
 	if err != nil {
-		return fmt.Errorf("[BindProxyHash] tb.MakeInvocationTransaction error: %s", err)
+		return fmt.Errorf("[BindProxyHash] MakeInvocationTx error: %w", err)
 	}
 	// sign transaction
-	err = tx.AddSignature(itx, this.Acc.KeyPair)
+	err = signer.SignNeoTx(itx, this.Signer)
 	if err != nil {
-		return fmt.Errorf("[BindProxyHash] tx.AddSignature error: %s", err)
+		return fmt.Errorf("[BindProxyHash] SignNeoTx error: %s", err)
 	}
 
 	rawTxString := itx.RawTransactionString()
 
 	// send the raw transaction
-	response := this.Cli.SendRawTransaction(rawTxString)
-	if response.HasError() {
+	if err = this.sendRawTransaction(rawTxString); err != nil {
 		return fmt.Errorf("[BindProxyHash] SendRawTransaction error: %s,  RawTransactionString: %s",
-			response.ErrorResponse.Error.Message, rawTxString)
+			err, rawTxString)
 	}
 
 	log.Infof("Neo bindProxyHash, txHash: %s", itx.HashString())
-	WaitNeoTx(this.Cli, itx.Hash)
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return fmt.Errorf("[BindProxyHash] waitTx error: %w", err)
+	}
 
 	return nil
 }
 
 func (this *NeoInvoker) BindAssetHash(neoLockProxy []byte, fromAssetHash []byte, toChainId uint64, toAssetHash []byte) (string, error) {
-	from, err := ParseNeoAddr(this.Acc.Address)
+	from, err := ParseNeoAddr(this.Signer.Address())
 	if err != nil {
-		return "", fmt.Errorf("[BindAssetHash], ParseNeoAddr acct: %s,  err: %v", this.Acc.Address, err)
+		return "", fmt.Errorf("[BindAssetHash], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
 	}
 	fromUint160, err := helper.UInt160FromBytes(from)
 	if err != nil {
 		return "", fmt.Errorf("[BindAssetHash], Uint160FromBytes err: %v", err)
 	}
-	fromAssetHashValue := sc.ContractParameter{
-		Type:  sc.ByteArray,
-		Value: fromAssetHash,
-	}
-	toChainIdValue := sc.ContractParameter{
-		Type:  sc.Integer,
-		Value: *big.NewInt(int64(toChainId)),
-	}
-	toAssetHashValue := sc.ContractParameter{
-		Type:  sc.ByteArray,
-		Value: toAssetHash,
-	}
-	// build script
-	scriptBuilder := sc.NewScriptBuilder()
-	args := []sc.ContractParameter{fromAssetHashValue, toChainIdValue, toAssetHashValue}
-	scriptBuilder.MakeInvocationScript(neoLockProxy, "bindAssetHash", args)
-	script := scriptBuilder.ToArray()
+	script := BindAssetHashScript(neoLockProxy, fromAssetHash, toChainId, toAssetHash)
 
 	// create an InvocationTransaction
-	tb := tx.NewTransactionBuilder(this.Cli.Endpoint.String())
-
-	sysFee := helper.Fixed8FromFloat64(0)
-	netFee := helper.Fixed8FromFloat64(0)
-
-	itx, err := tb.MakeInvocationTransaction(script, fromUint160, nil, fromUint160, sysFee, netFee)
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
 	if err != nil {
-		return "", fmt.Errorf("[BindAssetHash] tb.MakeInvocationTransaction error: %s", err)
+		return "", fmt.Errorf("[BindAssetHash] MakeInvocationTx error: %w", err)
 	}
 	// sign transaction
-	err = tx.AddSignature(itx, this.Acc.KeyPair)
+	err = signer.SignNeoTx(itx, this.Signer)
 	if err != nil {
-		return "", fmt.Errorf("[BindAssetHash] tx.AddSignature error: %s", err)
+		return "", fmt.Errorf("[BindAssetHash] SignNeoTx error: %s", err)
 	}
 
 	rawTxString := itx.RawTransactionString()
 
 	// send the raw transaction
-	response := this.Cli.SendRawTransaction(rawTxString)
-	if response.HasError() {
+	if err = this.sendRawTransaction(rawTxString); err != nil {
 		return "", fmt.Errorf("[BindAssetHash] SendRawTransaction error: %s,  RawTransactionString: %s",
-			response.ErrorResponse.Error.Message, rawTxString)
+			err, rawTxString)
 	}
 	log.Infof("Neo bindAssetHash, txHash: %s", itx.HashString())
-	WaitNeoTx(this.Cli, itx.Hash)
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return "", fmt.Errorf("[BindAssetHash] waitTx error: %w", err)
+	}
 
 	return itx.HashString(), nil
 }
 
-func (this *NeoInvoker) GetProxyOperator(neoLockProxy []byte) (string, error) {
+// BindProxyHashScript builds the script sent by BindProxyHash
+func BindProxyHashScript(neoLockProxy []byte, toChainId uint64, toProxyHash []byte) []byte {
+	toChainIdValue := sc.ContractParameter{
+		Type:  sc.Integer,
+		Value: *big.NewInt(int64(toChainId)),
+	}
+	toProxyHashValue := sc.ContractParameter{
+		Type:  sc.ByteArray,
+		Value: toProxyHash,
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	args := []sc.ContractParameter{toChainIdValue, toProxyHashValue}
+	scriptBuilder.MakeInvocationScript(neoLockProxy, "bindProxyHash", args)
+	return scriptBuilder.ToArray()
+}
+
+// BindAssetHashScript builds the script sent by BindAssetHash
+func BindAssetHashScript(neoLockProxy []byte, fromAssetHash []byte, toChainId uint64, toAssetHash []byte) []byte {
+	fromAssetHashValue := sc.ContractParameter{
+		Type:  sc.ByteArray,
+		Value: fromAssetHash,
+	}
+	toChainIdValue := sc.ContractParameter{
+		Type:  sc.Integer,
+		Value: *big.NewInt(int64(toChainId)),
+	}
+	toAssetHashValue := sc.ContractParameter{
+		Type:  sc.ByteArray,
+		Value: toAssetHash,
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	args := []sc.ContractParameter{fromAssetHashValue, toChainIdValue, toAssetHashValue}
+	scriptBuilder.MakeInvocationScript(neoLockProxy, "bindAssetHash", args)
+	return scriptBuilder.ToArray()
+}
+
+func (this *NeoInvoker) GetProxyOperator(neoLockProxy []byte) (NeoHash, error) {
 	scriptBuilder := sc.NewScriptBuilder()
 	args := []sc.ContractParameter{}
 	scriptBuilder.MakeInvocationScript(neoLockProxy, "getOperator", args)
 	script := scriptBuilder.ToArray()
 
-	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return "", fmt.Errorf("[GetProxyOperator], InvokeScript err: %v", response.Error)
-	}
-	for _, stack := range response.Result.Stack {
-		stack.Convert()
-
-		if stack.Type == "ByteArray" {
-			x, e := hex.DecodeString(stack.Value.(string))
-			if e != nil {
-				return "", fmt.Errorf("bytearray: %s, decodestring err: %v", stack.Value.(string), e)
-			}
-			base58Addr, _ := common.AddressParseFromBytes(x)
-			return base58Addr.ToBase58(), nil
-		}
+	items, err := this.invokeRead(script, 1)
+	if err != nil {
+		return nil, fmt.Errorf("[GetProxyOperator], invokeRead err: %w", err)
 	}
-	return "", fmt.Errorf("operator not found")
+	res, err := items[0].Hash()
+	if err != nil {
+		return nil, fmt.Errorf("[GetProxyOperator], %v", err)
+	}
+	return res, nil
 }
 
-func (this *NeoInvoker) GetProxyHash(neoLockProxy []byte, toChainId uint64) (string, error) {
+// GetProxyHash returns the lock proxy bound for toChainId as stored, empty if unbound
+func (this *NeoInvoker) GetProxyHash(neoLockProxy []byte, toChainId uint64) (NeoHash, error) {
 	scriptBuilder := sc.NewScriptBuilder()
 	args := []sc.ContractParameter{
 		sc.ContractParameter{
@@ -161,22 +149,19 @@ func (this *NeoInvoker) GetProxyHash(neoLockProxy []byte, toChainId uint64) (str
 	scriptBuilder.MakeInvocationScript(neoLockProxy, "getProxyHash", args)
 	script := scriptBuilder.ToArray()
 
-	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return "", fmt.Errorf("[GetProxyHash], InvokeScript err: %v", response.Error)
-	}
-	for _, stack := range response.Result.Stack {
-		stack.Convert()
-		if stack.Type == "ByteArray" {
-			return stack.Value.(string), nil
-		}
+	items, err := this.invokeRead(script, 1)
+	if err != nil {
+		return nil, fmt.Errorf("[GetProxyHash], invokeRead err: %w", err)
+	}
+	res, err := items[0].Hash()
+	if err != nil {
+		return nil, fmt.Errorf("[GetProxyHash], %v", err)
 	}
-	return "", fmt.Errorf("GetProxyHash not found")
+	return res, nil
 }
 
-func (this *NeoInvoker) GetAssetHashs(neoLockProxy []byte, toChainId uint64, fromAssetHashs [][]byte) ([]string, error) {
+// GetAssetHashs returns the asset hashes on toChainId bound for fromAssetHashs as stored, empty if unbound
+func (this *NeoInvoker) GetAssetHashs(neoLockProxy []byte, toChainId uint64, fromAssetHashs [][]byte) ([]NeoHash, error) {
 	scriptBuilder := sc.NewScriptBuilder()
 
 	for _, from := range fromAssetHashs {
@@ -193,18 +178,14 @@ func (this *NeoInvoker) GetAssetHashs(neoLockProxy []byte, toChainId uint64, fro
 	}
 	script := scriptBuilder.ToArray()
 
-	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return nil, fmt.Errorf("[GetProxyHash], InvokeScript err: %v", response.Error)
+	items, err := this.invokeRead(script, len(fromAssetHashs))
+	if err != nil {
+		return nil, fmt.Errorf("[GetAssetHashs], invokeRead err: %w", err)
 	}
-	res := make([]string, len(fromAssetHashs))
-	for i, stack := range response.Result.Stack {
-		stack.Convert()
-
-		if stack.Type == "ByteArray" {
-			res[i] = stack.Value.(string)
+	res := make([]NeoHash, len(fromAssetHashs))
+	for i, item := range items {
+		if res[i], err = item.Hash(); err != nil {
+			return nil, fmt.Errorf("[GetAssetHashs], asset %x: %v", fromAssetHashs[i], err)
 		}
 	}
 	return res, nil
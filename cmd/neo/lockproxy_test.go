@@ -4,102 +4,77 @@ import (
 	"encoding/hex"
 	"github.com/joeqian10/neo-gogogo/helper"
 	"github.com/joeqian10/neo-gogogo/sc"
-	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet"
 	"github.com/polynetwork/poly/common"
-	"github.com/skyinglyh1/poly_wrapper/config"
-	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
 	"math/big"
 	"testing"
 )
 
 func Test_GetOperator(t *testing.T) {
-	config.DefConfig.Init("./config.json")
-	invoker, err := NewNeoInvoker(config.DefConfig.NeoUrl, config.DefConfig.NeoWallet, config.DefConfig.NeoWalletPwd)
-	if err != nil {
-		t.Fatal(err)
-	}
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
 	ccmc, _ := ParseNeoAddr("0xe1695b1314a1331e3935481620417ed835669407")
-	neoLock, _ := ParseNeoAddr("0xedd2862dceb90b945210372d229f453f2b705f4f")
-	//polyNeoWrapper, _ := ParseNeoAddr("0x104057f879009326250ee1f5d60e2efd925024e6")
+	neoLock, _ := ParseNeoAddr(testLockHash)
+	operator, _ := ParseNeoAddr(acc.Address)
+	toProxy, _ := hex.DecodeString("d8ae73e06552e270340b63a8bcabf9277a1aac99")
+	toAsset, _ := hex.DecodeString("0000000000000000000000000000000000000000")
+	fake.setStorage(ccmc, []byte("IsInitGenesisBlock"), helper.BigIntToNeoBytes(big.NewInt(1000)))
+	fake.Reads["getOperator"] = hashItem(operator)
+	fake.Reads["getProxyHash"] = hashItem(toProxy)
+	fake.Reads["getAssetHash"] = hashItem(toAsset)
+	fake.Reads["balanceOf"] = intItem(5)
 
 	// check if initialized
-	{
-		res, err := invoker.GetStorage(ccmc, hex.EncodeToString([]byte("IsInitGenesisBlock")))
-		if err != nil {
-			log.Errorf("Initialized height err: %v", err)
-			return
-		}
-		log.Infof("ccmc initialized poly height on neo ccmc is: %s", res)
+	res, err := invoker.InspectCcmcStorage(ccmc)
+	if err != nil || !res.Initialized || res.GenesisHeight != 1000 {
+		t.Fatalf("ccmc initialized height: %+v, err: %v", res, err)
 	}
-
-	{
-		res, err := invoker.GetProxyOperator(neoLock)
-		if err != nil {
-			log.Errorf("GetOperator err: %v", err)
-			return
-		}
-		log.Infof("neoLockProxy operator: %s", res)
+	op, err := invoker.GetProxyOperator(neoLock)
+	if err != nil || op.Address() != acc.Address {
+		t.Fatalf("GetProxyOperator: %s, err: %v", op.Address(), err)
 	}
 
 	var toChainId uint64 = 79
-	{
-		res, err := invoker.GetProxyHash(neoLock, toChainId)
-		if err != nil {
-			log.Errorf("GetProxyHash err: %v", err)
-			return
-		}
-		log.Infof("neoLockProxy: %x, toChainId: %d, toChainProxyHash (little) : %s", neoLock, toChainId, res)
+	proxy, err := invoker.GetProxyHash(neoLock, toChainId)
+	if err != nil || proxy.LittleEndian() != hex.EncodeToString(toProxy) {
+		t.Fatalf("GetProxyHash: %s, err: %v", proxy.LittleEndian(), err)
 	}
-
-	fromAssetHashs := []string{
-		"0x17da3881ab2d050fea414c80b3fa8324d756f60e",
-	}
-	{
-		froms := make([][]byte, 0)
-		for _, from := range fromAssetHashs {
-			f, _ := ParseNeoAddr(from)
-			froms = append(froms, f)
-		}
-		res, err := invoker.GetAssetHashs(neoLock, toChainId, froms)
-		if err != nil {
-			log.Errorf("GetProxyHash err: %v", err)
-			return
-		}
-		bals, err := invoker.GetAssetBalances(neoLock, froms)
-		if err != nil {
-			log.Errorf("GetAssetBalances err: %v", err)
-			return
-		}
-
-		for i, _ := range fromAssetHashs {
-			log.Infof("neoLockProxy GetAssetHashs, toChainId: %d, from: %s, proxyBalance: %s, to(little): %s", toChainId, fromAssetHashs[i], bals[i].String(), res[i])
-		}
+	calls, _ := DecodeInvocationScript(fake.Invoked[len(fake.Invoked)-1])
+	if calls[0].Method != "getProxyHash" || argInt(calls[0].Args[0]) != 79 {
+		t.Fatalf("unexpected getProxyHash call: %+v", calls[0])
 	}
-}
 
-func Test_SendTxFromNeo(t *testing.T) {
-	config.DefConfig.Init("./config_test.json")
-	invoker, err := NewNeoInvoker(config.DefConfig.NeoUrl, config.DefConfig.NeoWallet, config.DefConfig.NeoWalletPwd)
-	if err != nil {
-		t.Fatal(err)
+	froms := make([][]byte, 0)
+	for _, from := range []string{testNNeo, "0x843e9f7a4ba7e062a53d7bbbe85cb35421704616"} {
+		f, _ := ParseNeoAddr(from)
+		froms = append(froms, f)
 	}
-
-	neoLockAddr, err := ParseNeoAddr("")
-	if err != nil {
-		return
+	assets, err := invoker.GetAssetHashs(neoLock, toChainId, froms)
+	if err != nil || len(assets) != 2 || !assets[1].IsZero() || len(assets[1]) != 20 {
+		t.Fatalf("GetAssetHashs: %v, err: %v", assets, err)
 	}
-	hrc20AddrOnNeo, err := ParseNeoAddr("config.DefConfig.NeoHrc20")
-	if err != nil {
-		return
+	bals, err := invoker.GetAssetBalances(neoLock, froms)
+	if err != nil || len(bals) != 2 || bals[0].Int64() != 5 {
+		t.Fatalf("GetAssetBalances: %v, err: %v", bals, err)
 	}
+}
+
+func Test_SendTxFromNeo(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	neoLockAddr, _ := ParseNeoAddr(testLockHash)
+	hrc20AddrOnNeo, _ := ParseNeoAddr(testNNeo)
 
 	fromAsset := sc.ContractParameter{
 		Type:  sc.ByteArray,
 		Value: hrc20AddrOnNeo,
 	}
-	rawFrom, err := helper.AddressToScriptHash(invoker.Acc.Address)
+	rawFrom, err := helper.AddressToScriptHash(invoker.Signer.Address())
 	if err != nil {
-		return
+		t.Fatal(err)
 	}
 	fromAddr := sc.ContractParameter{
 		Type:  sc.ByteArray,
@@ -117,23 +92,27 @@ func Test_SendTxFromNeo(t *testing.T) {
 		Type:  sc.Integer,
 		Value: *big.NewInt(int64(1)),
 	}
-	tb := tx.NewTransactionBuilder(config.DefConfig.NeoUrl)
 	sb := sc.NewScriptBuilder()
 	sb.MakeInvocationScript(neoLockAddr, "lock", []sc.ContractParameter{fromAsset, fromAddr, toChainId, toAddr, amt})
 	script := sb.ToArray()
 
-	itx, err := tb.MakeInvocationTransaction(script, rawFrom, nil, rawFrom, helper.Zero, helper.Zero)
-	if err != nil && err.Error() != "" {
-		return
-	}
-	err = tx.AddSignature(itx, invoker.Acc.KeyPair)
+	itx, _, err := invoker.MakeInvocationTx(script, rawFrom)
 	if err != nil {
-		return
+		t.Fatal(err)
+	}
+	if err = signer.SignNeoTx(itx, invoker.Signer); err != nil {
+		t.Fatal(err)
 	}
-	response := tb.Client.SendRawTransaction(itx.RawTransactionString())
+	response := invoker.Cli.SendRawTransaction(itx.RawTransactionString())
 	if response.HasError() {
-		return
+		t.Fatal(response.Error.Message)
+	}
+	conf, err := invoker.waitTx(itx.HashString())
+	if err != nil || conf.VMState != VMStateHalt {
+		t.Fatalf("lock tx %s: %+v, err: %v", itx.HashString(), conf, err)
+	}
+	calls := fake.sentCalls(0)
+	if hex.EncodeToString(calls[0].ScriptHash) != hex.EncodeToString(neoLockAddr) || calls[0].Method != "lock" || argInt(calls[0].Args[4]) != 1 {
+		t.Fatalf("unexpected lock calls: %+v", calls)
 	}
-
-	log.Infof("successful to send %d hrc20 from neo to heco", itx.HashString())
 }
@@ -0,0 +1,261 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet/keys"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"sort"
+)
+
+// MultiSigAccount is an m-of-n CHECKMULTISIG account, PublicKeys are in the ascending order of
+// the verification script, which is also the order its signatures must be pushed in
+type MultiSigAccount struct {
+	M                  int
+	PublicKeys         []*keys.PublicKey
+	VerificationScript []byte
+	ScriptHash         helper.UInt160
+	Address            string
+}
+
+// MultiSigPart collects the signatures of a multi-sig sender in a TxFile, keyed by the
+// compressed public key in hex. Each signer may add to a copy of the file, see MergeTxFiles
+type MultiSigPart struct {
+	VerificationScript string            `json:"verificationScript"`
+	Signatures         map[string]string `json:"signatures"`
+}
+
+// MultiSigThresholdError is returned while a multi-sig tx has less than M valid signatures
+type MultiSigThresholdError struct {
+	TxHash     string
+	Signatures int
+	M          int
+}
+
+func (this *MultiSigThresholdError) Error() string {
+	return fmt.Sprintf("tx %s has %d of the %d signatures needed", this.TxHash, this.Signatures, this.M)
+}
+
+// NewMultiSigAccount builds the m-of-n account of publicKeys, given as compressed hex
+func NewMultiSigAccount(m int, publicKeys []string) (*MultiSigAccount, error) {
+	if m < 1 || m > len(publicKeys) || len(publicKeys) > 1024 {
+		return nil, fmt.Errorf("NewMultiSigAccount, invalid %d of %d", m, len(publicKeys))
+	}
+	pubs := make([]*keys.PublicKey, len(publicKeys))
+	for i, s := range publicKeys {
+		p, err := keys.NewPublicKeyFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("NewMultiSigAccount, public key %s err: %v", s, err)
+		}
+		pubs[i] = p
+	}
+	sort.Sort(keys.PublicKeySlice(pubs))
+	for i := 1; i < len(pubs); i++ {
+		if pubs[i].Compare(pubs[i-1]) == 0 {
+			return nil, fmt.Errorf("NewMultiSigAccount, duplicated public key %s", pubs[i].String())
+		}
+	}
+	sb := sc.NewScriptBuilder()
+	sb.EmitPushInt(m)
+	for _, p := range pubs {
+		sb.EmitPushBytes(p.EncodeCompression())
+	}
+	sb.EmitPushInt(len(pubs))
+	sb.Emit(sc.CHECKMULTISIG)
+	script := sb.ToArray()
+	scriptHash, err := helper.BytesToScriptHash(script)
+	if err != nil {
+		return nil, fmt.Errorf("NewMultiSigAccount, BytesToScriptHash err: %v", err)
+	}
+	return &MultiSigAccount{
+		M:                  m,
+		PublicKeys:         pubs,
+		VerificationScript: script,
+		ScriptHash:         scriptHash,
+		Address:            helper.ScriptHashToAddress(scriptHash),
+	}, nil
+}
+
+// ParseMultiSigAccount reads m and the public keys back from a verification script
+func ParseMultiSigAccount(verificationScript []byte) (*MultiSigAccount, error) {
+	pos := 0
+	readInt := func() (int, error) {
+		if pos >= len(verificationScript) {
+			return 0, fmt.Errorf("script ends at %d", pos)
+		}
+		op := sc.OpCode(verificationScript[pos])
+		pos++
+		switch {
+		case op >= sc.PUSH1 && op <= sc.PUSH16:
+			return int(op-sc.PUSH1) + 1, nil
+		// PUSHBYTES1 or PUSHBYTES2 for numbers above 16
+		case op == sc.PUSHBYTES1 || op == sc.PUSHBYTES1+1:
+			n := int(op)
+			if pos+n > len(verificationScript) {
+				return 0, fmt.Errorf("script ends at %d", pos)
+			}
+			pos += n
+			return int(helper.BigIntFromNeoBytes(verificationScript[pos-n : pos]).Int64()), nil
+		}
+		return 0, fmt.Errorf("expect an integer at %d, got opcode 0x%02x", pos-1, byte(op))
+	}
+	m, err := readInt()
+	if err != nil {
+		return nil, fmt.Errorf("ParseMultiSigAccount, m: %v", err)
+	}
+	pubs := make([]string, 0)
+	for pos+34 <= len(verificationScript) && verificationScript[pos] == 33 {
+		pubs = append(pubs, hex.EncodeToString(verificationScript[pos+1:pos+34]))
+		pos += 34
+	}
+	if n, err := readInt(); err != nil || n != len(pubs) {
+		return nil, fmt.Errorf("ParseMultiSigAccount, %d public keys, n: %d, err: %v", len(pubs), n, err)
+	}
+	ms, err := NewMultiSigAccount(m, pubs)
+	if err != nil {
+		return nil, err
+	}
+	// rebuilding also rejects trailing opcodes and keys out of order
+	if !bytes.Equal(ms.VerificationScript, verificationScript) {
+		return nil, fmt.Errorf("ParseMultiSigAccount, %x is not a standard multi-sig script", verificationScript)
+	}
+	return ms, nil
+}
+
+// witnessSize is the size of the witness once M signatures are pushed, the count of witnesses is
+// in the size of the unsigned tx already
+func (this *MultiSigAccount) witnessSize() int {
+	invocation := 65 * this.M
+	return varIntSize(invocation) + invocation + varIntSize(len(this.VerificationScript)) + len(this.VerificationScript)
+}
+
+func varIntSize(n int) int {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	}
+	return 5
+}
+
+// BuildMultiSigTxFile builds the unsigned tx of script paid by a multi-sig sender
+func (this *NeoInvoker) BuildMultiSigTxFile(script []byte, ms *MultiSigAccount) (*TxFile, error) {
+	itx, estimate, err := this.makeInvocationTx(script, ms.ScriptHash, ms.witnessSize())
+	if err != nil {
+		return nil, fmt.Errorf("[BuildMultiSigTxFile] MakeInvocationTx error: %w", err)
+	}
+	f, err := newTxFile(itx, estimate, ms.Address)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildMultiSigTxFile], %v", err)
+	}
+	f.MultiSig = &MultiSigPart{
+		VerificationScript: helper.BytesToHex(ms.VerificationScript),
+		Signatures:         map[string]string{},
+	}
+	f.logSummary(fmt.Sprintf("built, needs %d of %d signatures", ms.M, len(ms.PublicKeys)))
+	return f, nil
+}
+
+// signMultiSig adds the signature of s to the MultiSigPart of f
+func signMultiSig(f *TxFile, itx *tx.InvocationTransaction, s signer.NeoSigner) error {
+	ms, err := f.multiSigAccount()
+	if err != nil {
+		return err
+	}
+	if !hasScriptAttribute(itx, ms.ScriptHash) {
+		return fmt.Errorf("multi-sig %s is not a sender of tx %s", ms.Address, f.TxHash)
+	}
+	pub := hex.EncodeToString(s.PublicKey().EncodeCompression())
+	member := false
+	for _, p := range ms.PublicKeys {
+		member = member || p.Compare(s.PublicKey()) == 0
+	}
+	if !member {
+		return fmt.Errorf("%s is not a member of multi-sig %s", s.Address(), ms.Address)
+	}
+	sig, err := s.SignNeo(itx.UnsignedRawTransaction())
+	if err != nil {
+		return fmt.Errorf("sign err: %v", err)
+	}
+	if f.MultiSig.Signatures == nil {
+		f.MultiSig.Signatures = map[string]string{}
+	}
+	f.MultiSig.Signatures[pub] = hex.EncodeToString(sig)
+	f.logSummary(fmt.Sprintf("signed by %s, %d of %d signatures", s.Address(), len(f.MultiSig.Signatures), ms.M))
+	return nil
+}
+
+// MergeTxFiles joins the signatures of copies of one multi-sig TxFile signed separately
+func MergeTxFiles(files ...*TxFile) (*TxFile, error) {
+	if len(files) == 0 || files[0].MultiSig == nil {
+		return nil, fmt.Errorf("MergeTxFiles, no multi-sig tx file")
+	}
+	merged := *files[0]
+	merged.Signed = ""
+	merged.MultiSig = &MultiSigPart{
+		VerificationScript: files[0].MultiSig.VerificationScript,
+		Signatures:         map[string]string{},
+	}
+	for i, f := range files {
+		if f.MultiSig == nil || f.Unsigned != merged.Unsigned || f.MultiSig.VerificationScript != merged.MultiSig.VerificationScript {
+			return nil, fmt.Errorf("MergeTxFiles, file %d is not a copy of multi-sig tx %s", i, merged.TxHash)
+		}
+		for pub, sig := range f.MultiSig.Signatures {
+			merged.MultiSig.Signatures[pub] = sig
+		}
+	}
+	return &merged, nil
+}
+
+// AssembleMultiSig builds the witness from the valid signatures of f in public key order and sets
+// f.Signed, it returns *MultiSigThresholdError while less than M signatures are valid
+func AssembleMultiSig(f *TxFile) error {
+	if f.MultiSig == nil {
+		return fmt.Errorf("[AssembleMultiSig], tx %s has no multi-sig sender", f.TxHash)
+	}
+	itx, err := f.decode(f.Unsigned)
+	if err != nil {
+		return fmt.Errorf("[AssembleMultiSig], %v", err)
+	}
+	ms, err := f.multiSigAccount()
+	if err != nil {
+		return fmt.Errorf("[AssembleMultiSig], %v", err)
+	}
+	msg := itx.UnsignedRawTransaction()
+	sb := sc.NewScriptBuilder()
+	count := 0
+	for _, p := range ms.PublicKeys {
+		if count == ms.M {
+			break
+		}
+		sig, err := hex.DecodeString(f.MultiSig.Signatures[hex.EncodeToString(p.EncodeCompression())])
+		if err != nil || len(sig) != 64 || !keys.VerifySignature(msg, sig, p) {
+			continue
+		}
+		sb.EmitPushBytes(sig)
+		count++
+	}
+	if count < ms.M {
+		return &MultiSigThresholdError{TxHash: f.TxHash, Signatures: count, M: ms.M}
+	}
+	witness, err := tx.CreateWitness(sb.ToArray(), ms.VerificationScript)
+	if err != nil {
+		return fmt.Errorf("[AssembleMultiSig], CreateWitness err: %v", err)
+	}
+	itx.Witnesses = []*tx.Witness{witness}
+	f.Signed = itx.RawTransactionString()
+	return nil
+}
+
+func (this *TxFile) multiSigAccount() (*MultiSigAccount, error) {
+	vs, err := hex.DecodeString(this.MultiSig.VerificationScript)
+	if err != nil {
+		return nil, fmt.Errorf("decode verification script err: %v", err)
+	}
+	return ParseMultiSigAccount(vs)
+}
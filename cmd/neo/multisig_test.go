@@ -0,0 +1,141 @@
+package neo
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"path/filepath"
+	"testing"
+)
+
+func Test_MultiSigAccount(t *testing.T) {
+	pubs := make([]string, 3)
+	for i := range pubs {
+		acc, _ := wallet.NewAccount()
+		pubs[i] = hex.EncodeToString(acc.KeyPair.PublicKey.EncodeCompression())
+	}
+	ms, err := NewMultiSigAccount(2, pubs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseMultiSigAccount(ms.VerificationScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.M != 2 || len(parsed.PublicKeys) != 3 || parsed.Address != ms.Address {
+		t.Fatalf("unexpected parsed account: %+v", parsed)
+	}
+	if _, err = NewMultiSigAccount(3, pubs[:2]); err == nil {
+		t.Fatal("m larger than n should be rejected")
+	}
+	if _, err = NewMultiSigAccount(1, []string{pubs[0], pubs[0]}); err == nil {
+		t.Fatal("duplicated keys should be rejected")
+	}
+	if _, err = ParseMultiSigAccount(append(ms.VerificationScript, 0x61)); err == nil {
+		t.Fatal("trailing opcodes should be rejected")
+	}
+}
+
+func Test_VerifyWitness_ManyKeys(t *testing.T) {
+	accs := make(map[string]*wallet.Account)
+	pubs := make([]string, 17)
+	for i := range pubs {
+		acc, _ := wallet.NewAccount()
+		pubs[i] = hex.EncodeToString(acc.KeyPair.PublicKey.EncodeCompression())
+		accs[pubs[i]] = acc
+	}
+	ms, err := NewMultiSigAccount(2, pubs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("unsigned tx")
+	sb := sc.NewScriptBuilder()
+	for _, p := range ms.PublicKeys[:2] {
+		sig, _ := accs[hex.EncodeToString(p.EncodeCompression())].KeyPair.Sign(msg)
+		sb.EmitPushBytes(sig)
+	}
+	witness := &tx.Witness{InvocationScript: sb.ToArray(), VerificationScript: ms.VerificationScript}
+	if !verifyWitness(msg, witness) {
+		t.Fatal("a witness of 17 keys, n pushed by PUSHBYTES1, should verify")
+	}
+	if verifyWitness([]byte("another tx"), witness) {
+		t.Fatal("a witness of another message should not verify")
+	}
+	witness.InvocationScript = witness.InvocationScript[:65]
+	if verifyWitness(msg, witness) {
+		t.Fatal("a witness below m signatures should not verify")
+	}
+}
+
+func Test_MultiSigTxFile(t *testing.T) {
+	accs := make([]*wallet.Account, 3)
+	pubs := make([]string, 3)
+	for i := range accs {
+		accs[i], _ = wallet.NewAccount()
+		pubs[i] = hex.EncodeToString(accs[i].KeyPair.PublicKey.EncodeCompression())
+	}
+	ms, _ := NewMultiSigAccount(2, pubs)
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"invokescript":         map[string]interface{}{"state": "HALT", "gas_consumed": "0.5", "stack": []interface{}{}},
+		"sendrawtransaction":   true,
+		"gettransactionheight": 10,
+		"getblockcount":        11,
+		"getapplicationlog":    applicationLog("HALT"),
+	})
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	script, _ := OwnerScript(wrapper, "pause", nil)
+	f, err := invoker.BuildMultiSigTxFile(script, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err = WriteTxFile(filepath.Join(dir, "unsigned.json"), f); err != nil {
+		t.Fatal(err)
+	}
+
+	// each signer signs its own copy
+	outsider, _ := wallet.NewAccount()
+	copies := make([]*TxFile, 0)
+	for _, acc := range []*wallet.Account{accs[2], accs[0], outsider} {
+		c, err := ReadTxFile(filepath.Join(dir, "unsigned.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = SignTxFile(c, signer.NewNeoKeySigner(acc.KeyPair))
+		if acc == outsider {
+			if err == nil {
+				t.Fatal("a key outside of the multi-sig should not sign")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		copies = append(copies, c)
+	}
+
+	_, err = invoker.BroadcastTxFile(copies[0])
+	var thresholdErr *MultiSigThresholdError
+	if !errors.As(err, &thresholdErr) || thresholdErr.Signatures != 1 || thresholdErr.M != 2 {
+		t.Fatalf("expect MultiSigThresholdError with 1 of 2 signatures, got: %v", err)
+	}
+
+	merged, err := MergeTxFiles(copies...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txHash, err := invoker.BroadcastTxFile(merged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txHash != f.TxHash {
+		t.Fatalf("broadcast tx %s, built %s", txHash, f.TxHash)
+	}
+	// the fee was estimated for the size of the assembled witness
+	if len(merged.Signed)/2 != len(merged.Unsigned)/2+ms.witnessSize() {
+		t.Fatalf("signed tx of %d bytes, expect %d + %d", len(merged.Signed)/2, len(merged.Unsigned)/2, ms.witnessSize())
+	}
+}
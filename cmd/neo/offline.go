@@ -0,0 +1,211 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet/keys"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"io/ioutil"
+)
+
+// TxFile carries an invocation tx between the build, sign and broadcast steps, so the key
+// signing it never has to be on a networked machine. Unsigned and Signed are the hex raw tx
+// without and with witnesses, Summary is DescribeScript of Script
+type TxFile struct {
+	Sender     string   `json:"sender"`
+	TxHash     string   `json:"txHash"`
+	Script     string   `json:"script"`
+	Summary    []string `json:"summary"`
+	SystemFee  string   `json:"systemFee"`
+	NetworkFee string   `json:"networkFee"`
+	Unsigned   string   `json:"unsigned"`
+	Signed     string   `json:"signed,omitempty"`
+	// MultiSig is set when the sender is a multi-sig account
+	MultiSig *MultiSigPart `json:"multiSig,omitempty"`
+}
+
+func ReadTxFile(path string) (*TxFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ReadTxFile, read %s err: %v", path, err)
+	}
+	f := &TxFile{}
+	if err = json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("ReadTxFile, unmarshal %s err: %v", path, err)
+	}
+	return f, nil
+}
+
+func WriteTxFile(path string, f *TxFile) error {
+	data, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return fmt.Errorf("WriteTxFile, marshal err: %v", err)
+	}
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("WriteTxFile, write %s err: %v", path, err)
+	}
+	return nil
+}
+
+// BuildTxFile builds the unsigned tx of script paid by sender, a base58 address. It needs the
+// rpc for fees and GAS inputs but no key
+func (this *NeoInvoker) BuildTxFile(script []byte, sender string) (*TxFile, error) {
+	from, err := helper.AddressToScriptHash(sender)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildTxFile], AddressToScriptHash %s err: %v", sender, err)
+	}
+	itx, estimate, err := this.MakeInvocationTx(script, from)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildTxFile] MakeInvocationTx error: %w", err)
+	}
+	f, err := newTxFile(itx, estimate, sender)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildTxFile], %v", err)
+	}
+	f.logSummary("built")
+	return f, nil
+}
+
+func newTxFile(itx *tx.InvocationTransaction, estimate *FeeEstimate, sender string) (*TxFile, error) {
+	summary, err := DescribeScript(itx.Script)
+	if err != nil {
+		return nil, fmt.Errorf("DescribeScript err: %v", err)
+	}
+	return &TxFile{
+		Sender:     sender,
+		TxHash:     itx.HashString(),
+		Script:     helper.BytesToHex(itx.Script),
+		Summary:    summary,
+		SystemFee:  estimate.SystemFee.String(),
+		NetworkFee: estimate.NetworkFee.String(),
+		Unsigned:   itx.RawTransactionString(),
+	}, nil
+}
+
+// SignTxFile adds the witness of s to f, or its signature if the sender is a multi-sig, it
+// works offline with a local signer. The tx is decoded again from Unsigned and checked against
+// TxHash and Script, so the summary shown is what gets signed
+func SignTxFile(f *TxFile, s signer.NeoSigner) error {
+	itx, err := f.decode(f.Unsigned)
+	if err != nil {
+		return fmt.Errorf("[SignTxFile], %v", err)
+	}
+	if f.MultiSig != nil {
+		if err = signMultiSig(f, itx, s); err != nil {
+			return fmt.Errorf("[SignTxFile], %v", err)
+		}
+		return nil
+	}
+	// a key which is not a sender would add its own script attribute and change the hash
+	if !hasScriptAttribute(itx, s.PublicKey().ScriptHash()) {
+		return fmt.Errorf("[SignTxFile], %s is not a sender of tx %s", s.Address(), f.TxHash)
+	}
+	if err = signer.SignNeoTx(itx, s); err != nil {
+		return fmt.Errorf("[SignTxFile] SignNeoTx error: %s", err)
+	}
+	f.Signed = itx.RawTransactionString()
+	f.logSummary("signed by " + s.Address())
+	return nil
+}
+
+// BroadcastTxFile sends a signed TxFile and waits for it like the other write methods. The
+// witness of a multi-sig sender is assembled first, so it fails until the threshold is reached
+func (this *NeoInvoker) BroadcastTxFile(f *TxFile) (string, error) {
+	if f.Signed == "" && f.MultiSig != nil {
+		if err := AssembleMultiSig(f); err != nil {
+			return "", fmt.Errorf("[BroadcastTxFile], %w", err)
+		}
+	}
+	if f.Signed == "" {
+		return "", fmt.Errorf("[BroadcastTxFile], tx %s is not signed", f.TxHash)
+	}
+	itx, err := f.decode(f.Signed)
+	if err != nil {
+		return "", fmt.Errorf("[BroadcastTxFile], %v", err)
+	}
+	for _, witness := range itx.Witnesses {
+		if !verifyWitness(itx.UnsignedRawTransaction(), witness) {
+			return "", fmt.Errorf("[BroadcastTxFile], invalid witness %x of tx %s", witness.VerificationScript, f.TxHash)
+		}
+	}
+	f.logSummary("broadcasting")
+
+	rawTxString := itx.RawTransactionString()
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return "", fmt.Errorf("[BroadcastTxFile] SendRawTransaction error: %s,  RawTransactionString: %s",
+			err, rawTxString)
+	}
+	log.Infof("Neo BroadcastTxFile, txHash: %s", itx.HashString())
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return "", fmt.Errorf("[BroadcastTxFile] waitTx error: %w", err)
+	}
+	return itx.HashString(), nil
+}
+
+// decode parses raw and checks that it is the tx described by f
+func (this *TxFile) decode(raw string) (*tx.InvocationTransaction, error) {
+	itx, err := (&tx.InvocationTransaction{Transaction: tx.NewTransaction()}).FromHexString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode raw tx err: %v", err)
+	}
+	if itx.Type != tx.Invocation_Transaction {
+		return nil, fmt.Errorf("tx type %d is not an invocation", itx.Type)
+	}
+	if hash := itx.HashString(); !sameTxHash(hash, this.TxHash) {
+		return nil, fmt.Errorf("raw tx hash %s does not match txHash %s", hash, this.TxHash)
+	}
+	if !bytes.Equal(itx.Script, helper.HexToBytes(this.Script)) {
+		return nil, fmt.Errorf("raw tx script does not match script of tx %s", this.TxHash)
+	}
+	summary, err := DescribeScript(itx.Script)
+	if err != nil {
+		return nil, fmt.Errorf("DescribeScript err: %v", err)
+	}
+	this.Summary = summary
+	return itx, nil
+}
+
+func (this *TxFile) logSummary(step string) {
+	log.Infof("NEO tx %s %s, sender: %s, system fee: %s GAS, network fee: %s GAS", this.TxHash, step, this.Sender, this.SystemFee, this.NetworkFee)
+	for i, line := range this.Summary {
+		log.Infof("  call %d: %s", i, line)
+	}
+}
+
+func hasScriptAttribute(itx *tx.InvocationTransaction, scriptHash helper.UInt160) bool {
+	for _, attr := range itx.Attributes {
+		if attr.Usage == tx.Script && bytes.Equal(attr.Data, scriptHash.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyWitness checks single and multi signature witnesses. The tx package verifiers index the
+// scripts without checking their lengths and take m and n for PUSH1 to PUSH16, so multi-sig
+// scripts are parsed by ParseMultiSigAccount, which also reads the PUSHBYTES of more than 16 keys
+func verifyWitness(msg []byte, witness *tx.Witness) bool {
+	inv := witness.InvocationScript
+	if len(inv) == 0 || len(inv)%65 != 0 {
+		return false
+	}
+	if len(witness.VerificationScript) == 35 {
+		return tx.VerifySignatureWitness(msg, witness)
+	}
+	ms, err := ParseMultiSigAccount(witness.VerificationScript)
+	if err != nil || len(inv)/65 < ms.M {
+		return false
+	}
+	sigs := make([][]byte, len(inv)/65)
+	for i := range sigs {
+		if inv[i*65] != 64 {
+			return false
+		}
+		sigs[i] = inv[i*65+1 : i*65+65]
+	}
+	return keys.VerifyMultiSig(msg, sigs, ms.PublicKeys)
+}
@@ -0,0 +1,117 @@
+package neo
+
+import (
+	"encoding/hex"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_TxFile(t *testing.T) {
+	owner, _ := wallet.NewAccount()
+	other, _ := wallet.NewAccount()
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"invokescript":         map[string]interface{}{"state": "HALT", "gas_consumed": "0.5", "stack": []interface{}{}},
+		"sendrawtransaction":   true,
+		"gettransactionheight": 10,
+		"getblockcount":        11,
+		"getapplicationlog":    applicationLog("HALT"),
+	})
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	script := BindProxyHashScript(wrapper, 79, wrapper)
+
+	// build online, no key needed
+	f, err := invoker.BuildTxFile(script, owner.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the bound proxy is bytes of the target chain, shown as they are sent
+	if len(f.Summary) != 1 || f.Summary[0] != testWrapper+".bindProxyHash(79, 0x"+NeoHash(wrapper).LittleEndian()+")" {
+		t.Fatalf("unexpected summary: %v", f.Summary)
+	}
+	path := filepath.Join(t.TempDir(), "tx.json")
+	if err = WriteTxFile(path, f); err != nil {
+		t.Fatal(err)
+	}
+
+	// sign offline
+	if f, err = ReadTxFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err = SignTxFile(f, signer.NewNeoKeySigner(other.KeyPair)); err == nil {
+		t.Fatal("a key which is not the sender should not sign")
+	}
+	tampered := *f
+	tampered.Script = strings.Replace(f.Script, "4f", "50", 1)
+	if err = SignTxFile(&tampered, signer.NewNeoKeySigner(owner.KeyPair)); err == nil {
+		t.Fatal("a script not matching the raw tx should not be signed")
+	}
+	if err = SignTxFile(f, signer.NewNeoKeySigner(owner.KeyPair)); err != nil {
+		t.Fatal(err)
+	}
+	if err = WriteTxFile(path, f); err != nil {
+		t.Fatal(err)
+	}
+
+	// broadcast online
+	if f, err = ReadTxFile(path); err != nil {
+		t.Fatal(err)
+	}
+	txHash, err := invoker.BroadcastTxFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txHash != f.TxHash {
+		t.Fatalf("broadcast tx %s, built %s", txHash, f.TxHash)
+	}
+
+	unsigned := *f
+	unsigned.Signed = ""
+	if _, err = invoker.BroadcastTxFile(&unsigned); err == nil {
+		t.Fatal("an unsigned tx should not be broadcast")
+	}
+}
+
+func Test_DescribeScript(t *testing.T) {
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	lines, err := DescribeScript(LockScript(wrapper, wrapper, wrapper, 79, []byte("to address longer than 20"), big.NewInt(1000), big.NewInt(-1), big.NewInt(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NeoHash(wrapper)
+	expected := testWrapper + ".lock(" + strings.Join([]string{
+		testWrapper + " (little endian " + h.LittleEndian() + ", " + h.Address() + ")",
+		testWrapper + " (little endian " + h.LittleEndian() + ", " + h.Address() + ")",
+		"79", "0x" + "746f2061646472657373206c6f6e676572207468616e203230", "1000", "-1", "0",
+	}, ", ") + ")"
+	if len(lines) != 1 || lines[0] != expected {
+		t.Fatalf("unexpected description:\n%v\nexpected:\n%s", lines, expected)
+	}
+
+	// 20 bytes of a bytes argument are not taken for a NEO hash
+	evmAddress, _ := hex.DecodeString("d8ae73e06552e270340b63a8bcabf9277a1aac99")
+	lines, err = DescribeScript(LockScript(wrapper, wrapper, wrapper, 2, evmAddress, big.NewInt(1000), big.NewInt(1), big.NewInt(0)))
+	if err != nil || !strings.Contains(lines[0], ", 2, 0xd8ae73e06552e270340b63a8bcabf9277a1aac99, 1000,") {
+		t.Fatalf("unexpected description of an EVM toAddress: %v, err: %v", lines, err)
+	}
+
+	// short and empty bytes are not taken for integers
+	lines, err = DescribeScript(SpeedUpScript(wrapper, wrapper, wrapper, []byte{}, big.NewInt(5)))
+	if err != nil || !strings.HasSuffix(lines[0], ", 0x, 5)") {
+		t.Fatalf("unexpected speedUp description: %v, err: %v", lines, err)
+	}
+	lines, err = DescribeScript(BindProxyHashScript(wrapper, 6, []byte{0x0a}))
+	if err != nil || !strings.HasSuffix(lines[0], ".bindProxyHash(6, 0x0a)") {
+		t.Fatalf("unexpected bindProxyHash description: %v, err: %v", lines, err)
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	scriptBuilder.MakeInvocationScript(wrapper, "unknown", []sc.ContractParameter{{Type: sc.ByteArray, Value: []byte{0x0a}}})
+	lines, err = DescribeScript(scriptBuilder.ToArray())
+	if err != nil || !strings.HasSuffix(lines[0], ".unknown(0x0a (10))") {
+		t.Fatalf("unexpected description of an unknown method: %v, err: %v", lines, err)
+	}
+}
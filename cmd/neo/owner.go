@@ -0,0 +1,132 @@
+package neo
+
+import (
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+)
+
+// OwnerTxResult is the outcome of an owner only invocation on the poly wrapper
+type OwnerTxResult struct {
+	Method string `json:"method"`
+	TxHash string `json:"txHash"`
+	// Param is the hash passed to the method in big endian, empty for pause and unpause
+	Param string `json:"param,omitempty"`
+}
+
+func (this *NeoInvoker) Paused(neoPolyWrapper []byte) (bool, error) {
+	scriptBuilder := sc.NewScriptBuilder()
+	args := []sc.ContractParameter{}
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "paused", args)
+	script := scriptBuilder.ToArray()
+
+	items, err := this.invokeRead(script, 1)
+	if err != nil {
+		return false, fmt.Errorf("[Paused], invokeRead err: %w", err)
+	}
+	paused, err := items[0].Bool()
+	if err != nil {
+		return false, fmt.Errorf("[Paused], %v", err)
+	}
+	return paused, nil
+}
+
+func (this *NeoInvoker) Pause(neoPolyWrapper []byte) (*OwnerTxResult, error) {
+	return this.invokeAsOwner(neoPolyWrapper, "pause", nil)
+}
+
+func (this *NeoInvoker) Unpause(neoPolyWrapper []byte) (*OwnerTxResult, error) {
+	return this.invokeAsOwner(neoPolyWrapper, "unpause", nil)
+}
+
+func (this *NeoInvoker) SetFeeCollector(neoPolyWrapper []byte, collector []byte) (*OwnerTxResult, error) {
+	return this.invokeAsOwner(neoPolyWrapper, "setFeeCollector", collector)
+}
+
+func (this *NeoInvoker) SetLockProxy(neoPolyWrapper []byte, neoLockProxy []byte) (*OwnerTxResult, error) {
+	return this.invokeAsOwner(neoPolyWrapper, "setLockProxy", neoLockProxy)
+}
+
+func (this *NeoInvoker) TransferOwnership(neoPolyWrapper []byte, newOwner []byte) (*OwnerTxResult, error) {
+	return this.invokeAsOwner(neoPolyWrapper, "transferOwnership", newOwner)
+}
+
+// invokeAsOwner checks that this.Signer owns the poly wrapper, then calls an owner only method with
+// an optional 20 bytes little endian hash as the only argument
+func (this *NeoInvoker) invokeAsOwner(neoPolyWrapper []byte, method string, param []byte) (*OwnerTxResult, error) {
+	res := &OwnerTxResult{Method: method}
+	if param != nil {
+		res.Param = NeoHash(param).BigEndian()
+	}
+	script, err := OwnerScript(neoPolyWrapper, method, param)
+	if err != nil {
+		return nil, fmt.Errorf("[%s], %v", method, err)
+	}
+	if res.TxHash, err = this.sendAsOwner(neoPolyWrapper, method, script); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// sendAsOwner checks that this.Signer owns the poly wrapper, then sends script and waits for it
+func (this *NeoInvoker) sendAsOwner(neoPolyWrapper []byte, method string, script []byte) (string, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return "", fmt.Errorf("[%s], ParseNeoAddr acct: %s,  err: %v", method, this.Signer.Address(), err)
+	}
+	fromUint160, err := helper.UInt160FromBytes(from)
+	if err != nil {
+		return "", fmt.Errorf("[%s], Uint160FromBytes err: %v", method, err)
+	}
+	owner, err := this.Owner(neoPolyWrapper)
+	if err != nil {
+		return "", fmt.Errorf("[%s], Owner err: %v", method, err)
+	}
+	if !owner.Equal(from) {
+		return "", fmt.Errorf("[%s], %s is not the owner of poly wrapper, owner: %s", method, this.Signer.Address(), owner.Address())
+	}
+
+	// create an InvocationTransaction
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
+	if err != nil {
+		return "", fmt.Errorf("[%s] MakeInvocationTx error: %w", method, err)
+	}
+	// sign transaction
+	err = signer.SignNeoTx(itx, this.Signer)
+	if err != nil {
+		return "", fmt.Errorf("[%s] SignNeoTx error: %s", method, err)
+	}
+
+	rawTxString := itx.RawTransactionString()
+
+	// send the raw transaction
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return "", fmt.Errorf("[%s] SendRawTransaction error: %s,  RawTransactionString: %s",
+			method, err, rawTxString)
+	}
+	log.Infof("Neo %s, txHash: %s", method, itx.HashString())
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return "", fmt.Errorf("[%s] waitTx error: %w", method, err)
+	}
+	return itx.HashString(), nil
+}
+
+// OwnerScript builds the script of an owner only method of the poly wrapper, param is the
+// 20 bytes little endian hash taken by setFeeCollector, setLockProxy and transferOwnership
+func OwnerScript(neoPolyWrapper []byte, method string, param []byte) ([]byte, error) {
+	args := []sc.ContractParameter{}
+	if param != nil {
+		if _, err := helper.UInt160FromBytes(param); err != nil {
+			return nil, fmt.Errorf("param Uint160FromBytes err: %v", err)
+		}
+		args = append(args, sc.ContractParameter{
+			Type:  sc.ByteArray,
+			Value: param,
+		})
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, method, args)
+	return scriptBuilder.ToArray(), nil
+}
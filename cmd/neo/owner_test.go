@@ -0,0 +1,52 @@
+package neo
+
+import (
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"testing"
+)
+
+func Test_Pause_PolyWrapper(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	polyNeoWrapper, _ := ParseNeoAddr(testWrapper)
+	owner, _ := ParseNeoAddr(acc.Address)
+	fake.Reads["owner"] = hashItem(owner)
+	fake.Reads["paused"] = map[string]interface{}{"type": "Boolean", "value": false}
+	fake.OnSent = func(calls []*ContractCall) {
+		fake.Reads["paused"] = map[string]interface{}{"type": "Boolean", "value": calls[0].Method == "pause"}
+	}
+
+	res, err := invoker.Pause(polyNeoWrapper)
+	if err != nil {
+		t.Fatalf("Pause err: %v", err)
+	}
+	if res.Method != "pause" || !sameTxHash(fake.Sent[0].HashString(), res.TxHash) {
+		t.Fatalf("unexpected pause result: %+v", res)
+	}
+	paused, err := invoker.Paused(polyNeoWrapper)
+	if err != nil {
+		t.Fatalf("Paused err: %v", err)
+	}
+	if !paused {
+		t.Fatal("neo poly wrapper should be paused")
+	}
+
+	res, err = invoker.Unpause(polyNeoWrapper)
+	if err != nil {
+		t.Fatalf("Unpause err: %v", err)
+	}
+	paused, err = invoker.Paused(polyNeoWrapper)
+	if err != nil {
+		t.Fatalf("Paused err: %v", err)
+	}
+	if paused {
+		t.Fatal("neo poly wrapper should not be paused")
+	}
+
+	// only the owner may pause, it is checked before anything is sent
+	other, _ := wallet.NewAccount()
+	if _, err = fake.invoker(other).Pause(polyNeoWrapper); err == nil || len(fake.Sent) != 2 {
+		t.Fatalf("a pause by another account should fail, err: %v, sent: %d", err, len(fake.Sent))
+	}
+}
@@ -0,0 +1,220 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"strings"
+)
+
+const (
+	BindingProxy = "proxy"
+	BindingAsset = "asset"
+
+	BindingActionNone   = "none"
+	BindingActionBind   = "bind"
+	BindingActionRebind = "rebind"
+)
+
+// Binding is a lock proxy binding wanted by config next to the one on chain. Current and Desired
+// are the stored bytes in hex, a hash of the target chain as that chain orders it
+type Binding struct {
+	Kind      string  `json:"kind"`
+	LockProxy NeoHash `json:"lockProxy"`
+	FromAsset NeoHash `json:"fromAsset,omitempty"`
+	ToChainId uint64  `json:"toChainId"`
+	Current   string  `json:"current"`
+	Desired   string  `json:"desired"`
+	Action    string  `json:"action"`
+	TxHash    string  `json:"txHash,omitempty"`
+	// Err is why the binding failed to be sent or returned false, see BindBatch
+	Err string `json:"err,omitempty"`
+}
+
+func (this *Binding) String() string {
+	target := fmt.Sprintf("chain %d", this.ToChainId)
+	if this.Kind == BindingAsset {
+		target = fmt.Sprintf("asset %s to chain %d", this.FromAsset.String(), this.ToChainId)
+	}
+	return fmt.Sprintf("%-6s %s of %s: %s -> %s", this.Action, this.Kind, target, orNone(this.Current), this.Desired)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// BindingPlan is the diff between the bindings of config and the chain, see PlanBindings
+type BindingPlan struct {
+	Bindings []*Binding `json:"bindings"`
+}
+
+// Changes returns the bindings to send, in the order of config with the proxies first
+func (this *BindingPlan) Changes() []*Binding {
+	res := make([]*Binding, 0)
+	for _, b := range this.Bindings {
+		if b.Action != BindingActionNone {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// Print logs one line per binding
+func (this *BindingPlan) Print() {
+	log.Infof("neo lock proxy binding plan, %d of %d bindings to send", len(this.Changes()), len(this.Bindings))
+	for _, b := range this.Bindings {
+		log.Infof("  %s", b.String())
+	}
+}
+
+// TargetChainHash decodes the hash of a contract on toChainId as the lock proxy stores it: NEO
+// hashes in the 0x big endian form are reversed like ParseNeoAddr, other chains keep their order
+func TargetChainHash(neoChainId, toChainId uint64, s string) ([]byte, error) {
+	if toChainId == neoChainId {
+		return ParseNeoAddr(s)
+	}
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+// PlanBindings reads the bindings of cfg.ProxyToBind and cfg.AssetToBind from NEO and compares
+// them with config. Entries from other chains are skipped, the asset bindings go to the lock
+// proxy of the NEO entry in ProxyToBind
+func (this *NeoInvoker) PlanBindings(cfg *config.TestConfig) (*BindingPlan, error) {
+	plan := &BindingPlan{Bindings: make([]*Binding, 0)}
+	var lockProxy NeoHash
+	for i, p := range cfg.ProxyToBind {
+		if p.FromChainId != cfg.NeoChainID {
+			continue
+		}
+		if p.FromProxy == "" || p.ToProxy == "" {
+			return nil, fmt.Errorf("[PlanBindings], proxyToBind %d has no fromProxy or toProxy", i)
+		}
+		proxy, err := ParseNeoHash(p.FromProxy)
+		if err != nil {
+			return nil, fmt.Errorf("[PlanBindings], proxyToBind %d fromProxy %s: %v", i, p.FromProxy, err)
+		}
+		if lockProxy != nil && !lockProxy.Equal(proxy) {
+			return nil, fmt.Errorf("[PlanBindings], proxyToBind has two neo lock proxies %s and %s", lockProxy.String(), proxy.String())
+		}
+		lockProxy = proxy
+		desired, err := TargetChainHash(cfg.NeoChainID, p.ToChainId, p.ToProxy)
+		if err != nil {
+			return nil, fmt.Errorf("[PlanBindings], proxyToBind %d toProxy %s: %v", i, p.ToProxy, err)
+		}
+		current, err := this.GetProxyHash(proxy, p.ToChainId)
+		if err != nil {
+			return nil, fmt.Errorf("[PlanBindings], %w", err)
+		}
+		plan.Bindings = append(plan.Bindings, newBinding(BindingProxy, proxy, nil, p.ToChainId, current, desired))
+	}
+
+	assets := make([]*Binding, 0)
+	for i, a := range cfg.AssetToBind {
+		if a.FromChainId != cfg.NeoChainID {
+			continue
+		}
+		if lockProxy == nil {
+			return nil, fmt.Errorf("[PlanBindings], assetToBind %d has no neo lock proxy in proxyToBind", i)
+		}
+		if a.FromAsset == "" || a.ToAsset == "" {
+			return nil, fmt.Errorf("[PlanBindings], assetToBind %d has no fromAsset or toAsset", i)
+		}
+		from, err := ParseNeoHash(a.FromAsset)
+		if err != nil {
+			return nil, fmt.Errorf("[PlanBindings], assetToBind %d fromAsset %s: %v", i, a.FromAsset, err)
+		}
+		desired, err := TargetChainHash(cfg.NeoChainID, a.ToChainId, a.ToAsset)
+		if err != nil {
+			return nil, fmt.Errorf("[PlanBindings], assetToBind %d toAsset %s: %v", i, a.ToAsset, err)
+		}
+		assets = append(assets, newBinding(BindingAsset, lockProxy, from, a.ToChainId, nil, desired))
+	}
+	// one read per target chain
+	byChain := make(map[uint64][]*Binding)
+	order := make([]uint64, 0)
+	for _, b := range assets {
+		if _, ok := byChain[b.ToChainId]; !ok {
+			order = append(order, b.ToChainId)
+		}
+		byChain[b.ToChainId] = append(byChain[b.ToChainId], b)
+	}
+	for _, chainId := range order {
+		froms := make([][]byte, len(byChain[chainId]))
+		for i, b := range byChain[chainId] {
+			froms[i] = b.FromAsset
+		}
+		current, err := this.GetAssetHashs(lockProxy, chainId, froms)
+		if err != nil {
+			return nil, fmt.Errorf("[PlanBindings], %w", err)
+		}
+		for i, b := range byChain[chainId] {
+			desired, _ := hex.DecodeString(b.Desired)
+			*b = *newBinding(BindingAsset, lockProxy, b.FromAsset, chainId, current[i], desired)
+		}
+	}
+	plan.Bindings = append(plan.Bindings, assets...)
+	return plan, nil
+}
+
+func newBinding(kind string, lockProxy, fromAsset NeoHash, toChainId uint64, current, desired []byte) *Binding {
+	b := &Binding{
+		Kind:      kind,
+		LockProxy: lockProxy,
+		FromAsset: fromAsset,
+		ToChainId: toChainId,
+		Current:   hex.EncodeToString(current),
+		Desired:   hex.EncodeToString(desired),
+		Action:    BindingActionNone,
+	}
+	switch {
+	case bytes.Equal(current, desired):
+	case NeoHash(current).IsZero():
+		b.Action = BindingActionBind
+	default:
+		b.Action = BindingActionRebind
+	}
+	return b
+}
+
+// ApplyBindings sends the changes of plan in batches, see BindBatch. The bindings sent keep their
+// TxHash, it fails if any of them failed
+func (this *NeoInvoker) ApplyBindings(plan *BindingPlan) error {
+	changes := plan.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+	res, err := this.BindBatch(changes, nil)
+	if err != nil {
+		return fmt.Errorf("[ApplyBindings], %w", err)
+	}
+	for _, b := range res.Succeeded() {
+		log.Infof("neo lock proxy %s done", b.String())
+	}
+	failed := res.Failed()
+	for _, b := range failed {
+		log.Errorf("neo lock proxy %s failed: %s", b.String(), b.Err)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("[ApplyBindings], %d of %d bindings failed, first %s: %s", len(failed), len(changes), failed[0].String(), failed[0].Err)
+	}
+	return nil
+}
+
+// ReconcileBindings plans the bindings of cfg, prints the plan and applies it. Running it again
+// once applied finds nothing to send
+func (this *NeoInvoker) ReconcileBindings(cfg *config.TestConfig) (*BindingPlan, error) {
+	plan, err := this.PlanBindings(cfg)
+	if err != nil {
+		return nil, err
+	}
+	plan.Print()
+	if err = this.ApplyBindings(plan); err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
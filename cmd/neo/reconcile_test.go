@@ -0,0 +1,65 @@
+package neo
+
+import (
+	"encoding/hex"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"testing"
+)
+
+func Test_ReconcileBindings(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	toProxy := "0xd8ae73e06552e270340b63a8bcabf9277a1aac99"
+	toAsset := "0x0000000000000000000000000000000000000001"
+	cfg := &config.TestConfig{
+		NeoChainID: 4,
+		ProxyToBind: []config.BindProxyStruct{
+			{FromChainId: 4, FromProxy: testLockHash, ToChainId: 2, ToProxy: toProxy},
+			{FromChainId: 2, FromProxy: toProxy, ToChainId: 4, ToProxy: testLockHash},
+		},
+		AssetToBind: []config.BindAssetStruct{
+			{FromChainId: 4, FromAsset: testNNeo, ToChainId: 2, ToAsset: toAsset},
+		},
+	}
+	// the proxy is bound already, the asset is not
+	proxyBytes, _ := hex.DecodeString(toProxy[2:])
+	fake.Reads["getProxyHash"] = hashItem(proxyBytes)
+	fake.Reads["bindAssetHash"] = boolItem(true)
+	fake.OnSent = func(calls []*ContractCall) {
+		if calls[0].Method == "bindAssetHash" {
+			fake.Reads["getAssetHash"] = hashItem(calls[0].Args[2].Bytes)
+		}
+	}
+
+	plan, err := invoker.ReconcileBindings(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Bindings) != 2 || plan.Bindings[0].Action != BindingActionNone || plan.Bindings[1].Action != BindingActionBind {
+		t.Fatalf("unexpected plan: %+v", plan.Bindings)
+	}
+	calls := fake.sentCalls(0)
+	if len(fake.Sent) != 1 || calls[0].Method != "bindAssetHash" || argInt(calls[0].Args[1]) != 2 || hex.EncodeToString(calls[0].Args[2].Bytes) != toAsset[2:] {
+		t.Fatalf("unexpected binding txs: %d, %+v", len(fake.Sent), calls)
+	}
+	if plan.Bindings[1].TxHash == "" {
+		t.Fatal("the asset binding should keep its tx hash")
+	}
+
+	// a second run is a no-op
+	if plan, err = invoker.ReconcileBindings(cfg); err != nil || len(plan.Changes()) != 0 || len(fake.Sent) != 1 {
+		t.Fatalf("second run: %d changes, %d txs, err: %v", len(plan.Changes()), len(fake.Sent), err)
+	}
+
+	// a changed proxy is rebound
+	cfg.ProxyToBind[0].ToProxy = toAsset
+	if plan, err = invoker.PlanBindings(cfg); err != nil || len(plan.Changes()) != 1 || plan.Changes()[0].Action != BindingActionRebind {
+		t.Fatalf("unexpected plan after a config change: %+v, err: %v", plan, err)
+	}
+	cfg.ProxyToBind[0].ToProxy = ""
+	if _, err = invoker.PlanBindings(cfg); err == nil {
+		t.Fatal("an incomplete entry should fail")
+	}
+}
@@ -0,0 +1,107 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// InvokeResult is the invokescript result with the notifications that rpc.RpcClient drops
+type InvokeResult struct {
+	models.InvokeResult
+	Notifications []models.RpcNotification `json:"notifications"`
+}
+
+// call sends a json rpc request to the invoker endpoint for methods whose result rpc.RpcClient can
+// not decode. With this.Endpoints a node which can not be reached fails over to the next one
+func (this *NeoInvoker) call(method string, params []interface{}, result interface{}) error {
+	attempts := 1
+	if this.Endpoints != nil {
+		attempts = len(this.Endpoints.Endpoints())
+	}
+	var err error
+	for i := 0; i < attempts; i++ {
+		this.useEndpoint()
+		var retry bool
+		if retry, err = this.post(method, params, result); err == nil || !retry || this.Endpoints == nil {
+			return err
+		}
+		this.Endpoints.Failover(this.Cli.Endpoint.String(), err)
+	}
+	return err
+}
+
+// post returns whether the error is of the node rather than of the request
+func (this *NeoInvoker) post(method string, params []interface{}, result interface{}) (bool, error) {
+	req, err := json.Marshal(rpc.NewRequest(method, params))
+	if err != nil {
+		return false, fmt.Errorf("marshal %s request err: %v", method, err)
+	}
+	cli := &http.Client{Timeout: 60 * time.Second}
+	resp, err := cli.Post(this.Cli.Endpoint.String(), "application/json", bytes.NewReader(req))
+	if err != nil {
+		return true, fmt.Errorf("post %s request err: %v", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return true, fmt.Errorf("post %s request, %s replied %s", method, this.Cli.Endpoint.String(), resp.Status)
+	}
+	out := struct {
+		rpc.ErrorResponse
+		Result json.RawMessage `json:"result"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return true, fmt.Errorf("decode %s response err: %v", method, err)
+	}
+	if out.HasError() {
+		return false, fmt.Errorf("%s err: %s", method, out.Error.Message)
+	}
+	if err = json.Unmarshal(out.Result, result); err != nil {
+		return false, fmt.Errorf("decode %s result err: %v", method, err)
+	}
+	return false, nil
+}
+
+// useEndpoint points Cli at the node picked by this.Endpoints. Only call and the scanner switch
+// nodes, ConfirmTx keeps Cli, so a sent tx is confirmed on the view of the node it was sent to
+func (this *NeoInvoker) useEndpoint() {
+	if this.Endpoints == nil {
+		return
+	}
+	if u := this.Endpoints.Current(); this.Cli == nil || u != this.Cli.Endpoint.String() {
+		this.Cli = rpc.NewClient(u)
+	}
+}
+
+// sendRawTransaction sends a signed tx, unlike rpc.RpcClient it reports a node which can not be
+// reached, and fails over like call
+func (this *NeoInvoker) sendRawTransaction(rawTx string) error {
+	var accepted bool
+	if err := this.call("sendrawtransaction", []interface{}{rawTx}, &accepted); err != nil {
+		return err
+	}
+	if !accepted {
+		return fmt.Errorf("tx rejected by %s", this.Cli.Endpoint.String())
+	}
+	return nil
+}
+
+// InvokeScript runs script with witness as the checked witness, a FAULT is returned as
+// *ContractFaultError when the contract notified the reason
+func (this *NeoInvoker) InvokeScript(script []byte, witness helper.UInt160) (*InvokeResult, error) {
+	res := &InvokeResult{}
+	err := this.call("invokescript", []interface{}{helper.BytesToHex(script), witness.String()}, res)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(res.State, VMStateFault) {
+		return res, newContractFaultError(scriptMethods(script), "", res.Notifications, &ScriptFaultError{GasConsumed: res.GasConsumed})
+	}
+	return res, nil
+}
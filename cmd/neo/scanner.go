@@ -0,0 +1,187 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	DefaultScanInterval = 15 * time.Second
+	// blocks scanned without events before the checkpoint is saved during a catch-up
+	scanSaveBlocks = 1000
+
+	invocationTxType = "InvocationTransaction"
+)
+
+// ScanEvent is a PolyWrapperLock or PolyWrapperSpeedUp found by the Scanner
+type ScanEvent struct {
+	Height uint32
+	Event  WrapperEvent
+}
+
+// FileCheckpoint persists the last height processed by a Scanner
+type FileCheckpoint struct {
+	Path string
+}
+
+type checkpointData struct {
+	Height uint32 `json:"height"`
+}
+
+// Load returns the saved height, ok is false if nothing is saved yet
+func (this *FileCheckpoint) Load() (height uint32, ok bool, err error) {
+	data, err := ioutil.ReadFile(this.Path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read checkpoint %s err: %v", this.Path, err)
+	}
+	cp := &checkpointData{}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return 0, false, fmt.Errorf("unmarshal checkpoint %s err: %v", this.Path, err)
+	}
+	return cp.Height, true, nil
+}
+
+// Save writes height to a temp file and renames it, so a crash never leaves a broken checkpoint
+func (this *FileCheckpoint) Save(height uint32) error {
+	data, _ := json.Marshal(&checkpointData{Height: height})
+	tmp, err := ioutil.TempFile(filepath.Dir(this.Path), filepath.Base(this.Path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("create checkpoint temp file err: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint err: %v", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close checkpoint err: %v", err)
+	}
+	if err = os.Rename(tmp.Name(), this.Path); err != nil {
+		return fmt.Errorf("rename checkpoint err: %v", err)
+	}
+	return nil
+}
+
+// Scanner walks NEO blocks and emits the lock and speedUp events of a poly wrapper. The
+// application log of every invocation tx is read, so calls reaching the wrapper through another
+// contract are found as well
+type Scanner struct {
+	Invoker        *NeoInvoker
+	NeoPolyWrapper []byte
+	StartHeight    uint32
+	Checkpoint     *FileCheckpoint
+	// Interval is the wait before polling again once the scanner reaches the chain tip
+	Interval time.Duration
+}
+
+func NewScanner(invoker *NeoInvoker, neoPolyWrapper []byte, startHeight uint32, checkpointPath string) (*Scanner, error) {
+	if _, err := helper.UInt160FromBytes(neoPolyWrapper); err != nil {
+		return nil, fmt.Errorf("NewScanner, wrapper Uint160FromBytes err: %v", err)
+	}
+	return &Scanner{
+		Invoker:        invoker,
+		NeoPolyWrapper: neoPolyWrapper,
+		StartHeight:    startHeight,
+		Checkpoint:     &FileCheckpoint{Path: checkpointPath},
+		Interval:       DefaultScanInterval,
+	}, nil
+}
+
+// Run scans from the checkpoint, or StartHeight if there is none, until ctx is done. Events are
+// sent to sink before the height of their block is saved, so an event may be sent again after a
+// restart but is never lost. The checkpoint is saved after blocks with events, every
+// scanSaveBlocks blocks, at the chain tip and on exit
+func (this *Scanner) Run(ctx context.Context, sink chan<- *ScanEvent) error {
+	height := this.StartHeight
+	saved, ok, err := this.Checkpoint.Load()
+	if err != nil {
+		return err
+	}
+	if ok {
+		height = saved + 1
+	}
+	log.Infof("[Scanner], start scanning neo poly wrapper events from height %d", height)
+	// blocks scanned since the last save, the last one is height - 1
+	unsaved := 0
+	save := func() error {
+		if unsaved == 0 {
+			return nil
+		}
+		unsaved = 0
+		return this.Checkpoint.Save(height - 1)
+	}
+	for {
+		this.Invoker.useEndpoint()
+		count := this.Invoker.Cli.GetBlockCount()
+		if count.HasError() {
+			log.Errorf("[Scanner], GetBlockCount err: %s", count.Error.Message)
+		}
+		for !count.HasError() && int(height) < count.Result && ctx.Err() == nil {
+			sent, err := this.ScanBlock(ctx, height, sink)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Errorf("[Scanner], scan block %d err: %v", height, err)
+				}
+				break
+			}
+			height++
+			unsaved++
+			if sent > 0 || unsaved >= scanSaveBlocks {
+				if err = save(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := save(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(this.Interval):
+		}
+	}
+}
+
+// ScanBlock sends the events of the poly wrapper in block height to sink and returns how many
+// were sent
+func (this *Scanner) ScanBlock(ctx context.Context, height uint32, sink chan<- *ScanEvent) (int, error) {
+	block := this.Invoker.Cli.GetBlockByIndex(height)
+	if block.HasError() {
+		return 0, fmt.Errorf("GetBlockByIndex err: %s", block.Error.Message)
+	}
+	sent := 0
+	for _, t := range block.Result.Tx {
+		if t.Type != invocationTxType {
+			continue
+		}
+		events, err := this.Invoker.GetWrapperEvents(this.NeoPolyWrapper, t.Txid)
+		if err != nil {
+			return sent, err
+		}
+		for _, event := range events {
+			switch event.(type) {
+			case *PolyWrapperLock, *PolyWrapperSpeedUp:
+			default:
+				continue
+			}
+			select {
+			case sink <- &ScanEvent{Height: height, Event: event}:
+				sent++
+			case <-ctx.Done():
+				return sent, ctx.Err()
+			}
+		}
+	}
+	return sent, nil
+}
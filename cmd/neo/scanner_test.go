@@ -0,0 +1,125 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newScanInvoker serves a chain of count blocks where block 2 holds a lock of testWrapper called
+// through another contract, tx 0x02, and one calling it directly
+func newScanInvoker(t *testing.T, count int) *NeoInvoker {
+	appLog := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(testAppLog), &appLog); err != nil {
+		t.Fatal(err)
+	}
+	indirectLog := map[string]interface{}{}
+	json.Unmarshal([]byte(testAppLog), &indirectLog)
+	indirectLog["txid"] = "0x02"
+	block := func(index int) map[string]interface{} {
+		txs := []interface{}{map[string]interface{}{"txid": "0x01", "type": "MinerTransaction"}}
+		if index == 2 {
+			txs = append(txs,
+				map[string]interface{}{"txid": "0x02", "type": "InvocationTransaction", "script": "00"},
+				map[string]interface{}{"txid": testTxHash, "type": "InvocationTransaction", "script": helper.BytesToHex(lockScript())})
+		}
+		return map[string]interface{}{"index": index, "tx": txs}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+		switch req.Method {
+		case "getblockcount":
+			resp["result"] = count
+		case "getblock":
+			resp["result"] = block(int(req.Params[0].(float64)))
+		case "getapplicationlog":
+			switch req.Params[0] {
+			case testTxHash:
+				resp["result"] = appLog
+			case "0x02":
+				resp["result"] = indirectLog
+			default:
+				t.Errorf("unexpected getapplicationlog of %v", req.Params[0])
+			}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return &NeoInvoker{Cli: rpc.NewClient(srv.URL)}
+}
+
+func Test_Scanner_Run(t *testing.T) {
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint.json")
+	scanner, err := NewScanner(newScanInvoker(t, 4), wrapper, 1, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanner.Interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan *ScanEvent, 4)
+	done := make(chan error)
+	go func() { done <- scanner.Run(ctx, events) }()
+
+	for _, txHash := range []string{"0x02", testTxHash} {
+		select {
+		case e := <-events:
+			lock, ok := e.Event.(*PolyWrapperLock)
+			if !ok || e.Height != 2 || lock.TxHash != txHash || lock.ToChainId != 79 {
+				t.Fatalf("unexpected event: %+v", e)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("lock of %s not scanned", txHash)
+		}
+	}
+	// wait for the scanner to reach the tip
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		if height, ok, _ := scanner.Checkpoint.Load(); ok && height == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("checkpoint not saved")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err = <-done; err != context.Canceled {
+		t.Fatalf("expect context.Canceled, got: %v", err)
+	}
+
+	// a restarted scanner resumes after the checkpoint and does not send the lock again
+	scanner, _ = NewScanner(newScanInvoker(t, 6), wrapper, 1, checkpoint)
+	if sent, err := scanner.ScanBlock(context.Background(), 2, events); err != nil || sent != 2 || len(events) != 2 {
+		t.Fatalf("ScanBlock should find both locks, sent %d, err: %v", sent, err)
+	}
+	<-events
+	<-events
+	ctx, cancel = context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	scanner.Interval = 10 * time.Millisecond
+	if err = scanner.Run(ctx, events); err != context.DeadlineExceeded {
+		t.Fatalf("expect context.DeadlineExceeded, got: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("resumed scanner should not send old events, got %d", len(events))
+	}
+	if height, _, _ := scanner.Checkpoint.Load(); height != 5 {
+		t.Fatalf("expect checkpoint 5, got %d", height)
+	}
+}
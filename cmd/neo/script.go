@@ -0,0 +1,244 @@
+package neo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"strings"
+)
+
+// ScriptItem is a value pushed by an invocation script, Array is set for items built by PACK
+type ScriptItem struct {
+	Bytes   []byte
+	Array   []ScriptItem
+	IsArray bool
+}
+
+// ContractCall is an APPCALL or SYSCALL decoded from an invocation script. ScriptHash is little
+// endian and empty for a SYSCALL, Syscall is the api name or its hex encoded hash if compressed
+type ContractCall struct {
+	ScriptHash []byte
+	Method     string
+	Syscall    string
+	Args       []ScriptItem
+}
+
+// DecodeInvocationScript replays the pushes of a script built by sc.ScriptBuilder and returns its calls
+func DecodeInvocationScript(script []byte) ([]*ContractCall, error) {
+	calls := make([]*ContractCall, 0)
+	stack := make([]ScriptItem, 0)
+	pop := func() (ScriptItem, error) {
+		if len(stack) == 0 {
+			return ScriptItem{}, fmt.Errorf("stack underflow")
+		}
+		item := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return item, nil
+	}
+	read := func(pos, n int) ([]byte, error) {
+		if n < 0 || pos+n > len(script) {
+			return nil, fmt.Errorf("script ends at %d, needs %d bytes from %d", len(script), n, pos)
+		}
+		return script[pos : pos+n], nil
+	}
+
+	for pc := 0; pc < len(script); {
+		op := sc.OpCode(script[pc])
+		pc++
+		switch {
+		case op == sc.PUSH0:
+			stack = append(stack, ScriptItem{Bytes: []byte{}})
+		case op >= sc.PUSHBYTES1 && op <= sc.PUSHBYTES75:
+			data, err := read(pc, int(op))
+			if err != nil {
+				return nil, err
+			}
+			pc += int(op)
+			stack = append(stack, ScriptItem{Bytes: data})
+		case op == sc.PUSHDATA1 || op == sc.PUSHDATA2 || op == sc.PUSHDATA4:
+			sizeLen := map[sc.OpCode]int{sc.PUSHDATA1: 1, sc.PUSHDATA2: 2, sc.PUSHDATA4: 4}[op]
+			sizeBs, err := read(pc, sizeLen)
+			if err != nil {
+				return nil, err
+			}
+			pc += sizeLen
+			size := int(binary.LittleEndian.Uint32(append(append([]byte{}, sizeBs...), make([]byte, 4-sizeLen)...)))
+			data, err := read(pc, size)
+			if err != nil {
+				return nil, err
+			}
+			pc += size
+			stack = append(stack, ScriptItem{Bytes: data})
+		case op == sc.PUSHM1:
+			stack = append(stack, ScriptItem{Bytes: []byte{0xff}})
+		case op >= sc.PUSH1 && op <= sc.PUSH16:
+			stack = append(stack, ScriptItem{Bytes: []byte{byte(op - sc.PUSH1 + 1)}})
+		case op == sc.PACK:
+			size, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			n := int(helper.BigIntFromNeoBytes(size.Bytes).Int64())
+			array := ScriptItem{IsArray: true, Array: make([]ScriptItem, n)}
+			for i := 0; i < n; i++ {
+				if array.Array[i], err = pop(); err != nil {
+					return nil, err
+				}
+			}
+			stack = append(stack, array)
+		case op == sc.APPCALL || op == sc.TAILCALL:
+			hash, err := read(pc, 20)
+			if err != nil {
+				return nil, err
+			}
+			pc += 20
+			call := &ContractCall{ScriptHash: hash}
+			method, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			call.Method = string(method.Bytes)
+			if len(stack) > 0 && stack[len(stack)-1].IsArray {
+				args, _ := pop()
+				call.Args = args.Array
+			} else if len(stack) > 0 && len(stack[len(stack)-1].Bytes) == 0 {
+				// PUSHF stands for no args
+				pop()
+			}
+			calls = append(calls, call)
+			// placeholder for the return value
+			stack = append(stack, ScriptItem{})
+		case op == sc.SYSCALL:
+			size, err := read(pc, 1)
+			if err != nil {
+				return nil, err
+			}
+			api, err := read(pc+1, int(size[0]))
+			if err != nil {
+				return nil, err
+			}
+			pc += 1 + int(size[0])
+			call := &ContractCall{Syscall: string(api)}
+			if len(api) == 4 {
+				call.Syscall = fmt.Sprintf("%x", api)
+			}
+			for len(stack) > 0 {
+				arg, _ := pop()
+				call.Args = append(call.Args, arg)
+			}
+			calls = append(calls, call)
+			stack = append(stack, ScriptItem{})
+		case op == sc.DROP || op == sc.THROWIFNOT:
+			if _, err := pop(); err != nil {
+				return nil, err
+			}
+		case op == sc.NOP || op == sc.RET:
+		default:
+			return nil, fmt.Errorf("unsupported opcode 0x%02x at %d", byte(op), pc-1)
+		}
+	}
+	return calls, nil
+}
+
+// scriptMethods lists the methods called by script, joined by ","
+func scriptMethods(script []byte) string {
+	calls, err := DecodeInvocationScript(script)
+	if err != nil {
+		return ""
+	}
+	methods := make([]string, 0, len(calls))
+	for _, call := range calls {
+		if call.Syscall != "" {
+			methods = append(methods, call.Syscall)
+		} else {
+			methods = append(methods, call.Method)
+		}
+	}
+	return strings.Join(methods, ",")
+}
+
+// kinds of the arguments of the known methods, which DescribeScript renders by position
+const (
+	hashArg  = "hash"
+	intArg   = "int"
+	bytesArg = "bytes"
+	textArg  = "text"
+)
+
+// methodArgKinds are the argument kinds of the poly wrapper, see NeoWrapper.cs, of the lock
+// proxy and of NEP-5 tokens
+var methodArgKinds = map[string][]string{
+	"lock":              {hashArg, hashArg, intArg, bytesArg, intArg, intArg, intArg},
+	"speedUp":           {hashArg, hashArg, bytesArg, intArg},
+	"extractFee":        {hashArg},
+	"setFeeCollector":   {hashArg},
+	"setLockProxy":      {hashArg},
+	"transferOwnership": {hashArg},
+	"migrate":           {bytesArg, bytesArg, intArg, intArg, textArg, textArg, textArg, textArg, textArg},
+	"bindProxyHash":     {intArg, bytesArg},
+	"bindAssetHash":     {hashArg, intArg, bytesArg},
+	"getProxyHash":      {intArg},
+	"getAssetHash":      {hashArg, intArg},
+	"balanceOf":         {hashArg},
+	"transfer":          {hashArg, hashArg, intArg},
+}
+
+// DescribeScript renders each call of script on one line for people reviewing a tx before they
+// sign it, e.g. 0xcd07...86a1.lock(0x17da...f60e (...), ..., 79, ...). Arguments of the known
+// methods are rendered by their kind: 20 bytes hashes in both byte orders with their address
+// since they may be hashes of another chain, integers in decimal and bytes in hex. Short arguments
+// of other methods are shown both in hex and as integers
+func DescribeScript(script []byte) ([]string, error) {
+	calls, err := DecodeInvocationScript(script)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, len(calls))
+	for _, call := range calls {
+		if call.Syscall != "" {
+			lines = append(lines, fmt.Sprintf("syscall %s", call.Syscall))
+			continue
+		}
+		kinds := methodArgKinds[call.Method]
+		args := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			kind := ""
+			if i < len(kinds) {
+				kind = kinds[i]
+			}
+			args[i] = describeItem(arg, kind)
+		}
+		lines = append(lines, fmt.Sprintf("%s.%s(%s)", NeoHash(call.ScriptHash).BigEndian(), call.Method, strings.Join(args, ", ")))
+	}
+	return lines, nil
+}
+
+// describeItem renders item as kind, an empty kind if unknown
+func describeItem(item ScriptItem, kind string) string {
+	if item.IsArray {
+		elems := make([]string, len(item.Array))
+		for i, e := range item.Array {
+			elems[i] = describeItem(e, "")
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+	hexBytes := "0x" + helper.BytesToHex(item.Bytes)
+	switch {
+	case kind == intArg:
+		return helper.BigIntFromNeoBytes(item.Bytes).String()
+	case kind == textArg:
+		return fmt.Sprintf("%q", item.Bytes)
+	case kind == bytesArg:
+		// e.g. an EVM address, in the order it is sent
+		return hexBytes
+	case len(item.Bytes) == 20:
+		h := NeoHash(item.Bytes)
+		return fmt.Sprintf("%s (little endian %s, %s)", h.BigEndian(), h.LittleEndian(), h.Address())
+	case kind == hashArg:
+		return fmt.Sprintf("%s (not a hash, %d bytes)", hexBytes, len(item.Bytes))
+	case kind == "" && len(item.Bytes) <= 8:
+		return fmt.Sprintf("%s (%s)", hexBytes, helper.BigIntFromNeoBytes(item.Bytes).String())
+	}
+	return hexBytes
+}
@@ -0,0 +1,91 @@
+package neo
+
+import (
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"math/big"
+	"strings"
+)
+
+// Simulation is the outcome of running a write script through invokescript with the sender as
+// witness, nothing is signed or sent. FaultReason is set when the contract notified why it faulted
+type Simulation struct {
+	Method        string                   `json:"method"`
+	Sender        string                   `json:"sender"`
+	Script        string                   `json:"script"`
+	VMState       string                   `json:"vmState"`
+	GasConsumed   string                   `json:"gasConsumed"`
+	Notifications []models.RpcNotification `json:"notifications"`
+	FaultReason   string                   `json:"faultReason,omitempty"`
+	FaultContract string                   `json:"faultContract,omitempty"`
+}
+
+// Halted is true if the script would succeed when sent now
+func (this *Simulation) Halted() bool {
+	return strings.Contains(this.VMState, VMStateHalt) && !strings.Contains(this.VMState, VMStateFault)
+}
+
+// Simulate runs script with this.Signer as the checked witness. A FAULT is not an error here, it
+// is reported in the returned Simulation
+func (this *NeoInvoker) Simulate(script []byte) (*Simulation, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("[Simulate], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	fromUint160, err := helper.UInt160FromBytes(from)
+	if err != nil {
+		return nil, fmt.Errorf("[Simulate], Uint160FromBytes err: %v", err)
+	}
+	res, err := this.InvokeScript(script, fromUint160)
+	if res == nil {
+		return nil, fmt.Errorf("[Simulate], InvokeScript err: %w", err)
+	}
+	sim := &Simulation{
+		Method:        scriptMethods(script),
+		Sender:        this.Signer.Address(),
+		Script:        helper.BytesToHex(script),
+		VMState:       res.State,
+		GasConsumed:   res.GasConsumed,
+		Notifications: res.Notifications,
+	}
+	if sim.Notifications == nil {
+		sim.Notifications = []models.RpcNotification{}
+	}
+	if !sim.Halted() {
+		sim.FaultReason, sim.FaultContract, _ = FaultReason(res.Notifications)
+	}
+	return sim, nil
+}
+
+func (this *NeoInvoker) SimulateLock(neoPolyWrapper []byte, fromAssetHash []byte, toChainId uint64, toAddress []byte, amount *big.Int, fee *big.Int, id *big.Int) (*Simulation, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("[SimulateLock], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	return this.Simulate(LockScript(neoPolyWrapper, fromAssetHash, from, toChainId, toAddress, amount, fee, id))
+}
+
+func (this *NeoInvoker) SimulateSpeedUp(neoPolyWrapper []byte, fromAssetHash []byte, txHash string, fee *big.Int) (*Simulation, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("[SimulateSpeedUp], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	lockTxHash, err := this.findLockTxHash(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("[SimulateSpeedUp], findLockTxHash err: %v", err)
+	}
+	return this.Simulate(SpeedUpScript(neoPolyWrapper, fromAssetHash, from, lockTxHash.Bytes(), fee))
+}
+
+func (this *NeoInvoker) SimulateExtractFee(neoPolyWrapper []byte, token []byte) (*Simulation, error) {
+	return this.Simulate(ExtractFeeScript(neoPolyWrapper, token))
+}
+
+func (this *NeoInvoker) SimulateBindProxyHash(neoLockProxy []byte, toChainId uint64, toProxyHash []byte) (*Simulation, error) {
+	return this.Simulate(BindProxyHashScript(neoLockProxy, toChainId, toProxyHash))
+}
+
+func (this *NeoInvoker) SimulateBindAssetHash(neoLockProxy []byte, fromAssetHash []byte, toChainId uint64, toAssetHash []byte) (*Simulation, error) {
+	return this.Simulate(BindAssetHashScript(neoLockProxy, fromAssetHash, toChainId, toAssetHash))
+}
@@ -0,0 +1,60 @@
+package neo
+
+import (
+	"encoding/json"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/signer"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_SimulateLock(t *testing.T) {
+	acc, err := wallet.NewAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params []interface{}
+	state := "FAULT, BREAK"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "invokescript" {
+			t.Errorf("simulation should only call invokescript, called %s", req.Method)
+		}
+		params = req.Params
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": map[string]interface{}{
+			"state":         state,
+			"gas_consumed":  "1.2",
+			"stack":         []interface{}{},
+			"notifications": []interface{}{faultNotification(FaultAmountLessThanFee)},
+		}})
+	}))
+	defer srv.Close()
+	invoker := NewNeoInvokerWithSigner(srv.URL, signer.NewNeoKeySigner(acc.KeyPair))
+
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	sim, err := invoker.SimulateLock(wrapper, wrapper, 79, wrapper, big.NewInt(1), big.NewInt(2), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, _ := ParseNeoAddr(acc.Address)
+	script := LockScript(wrapper, wrapper, from, 79, wrapper, big.NewInt(1), big.NewInt(2), big.NewInt(0))
+	sender, _ := helper.UInt160FromBytes(from)
+	if len(params) != 2 || params[0] != helper.BytesToHex(script) || params[1] != sender.String() {
+		t.Fatalf("lock script should be invoked with the sender as witness, params: %v", params)
+	}
+	if sim.Halted() || sim.Method != "lock" || sim.GasConsumed != "1.2" || sim.FaultReason != FaultAmountLessThanFee {
+		t.Fatalf("unexpected simulation: %+v", sim)
+	}
+
+	state = "HALT"
+	if sim, err = invoker.SimulateBindAssetHash(wrapper, wrapper, 79, wrapper); err != nil || !sim.Halted() || sim.Method != "bindAssetHash" {
+		t.Fatalf("unexpected simulation: %+v, %v", sim, err)
+	}
+}
@@ -0,0 +1,241 @@
+package neo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"math/big"
+	"strings"
+)
+
+// stack item types returned by invokescript
+const (
+	StackByteArray        = "ByteArray"
+	StackInteger          = "Integer"
+	StackBoolean          = "Boolean"
+	StackArray            = "Array"
+	StackStruct           = "Struct"
+	StackInteropInterface = "InteropInterface"
+)
+
+// StackItem is a decoded invokescript stack item. ByteArray, Integer and Boolean items share the
+// NEO VM byte representation, so each of them can be read as bytes, integer or boolean like the
+// VM does. Array and Struct items hold their elements, InteropInterface items hold nothing
+type StackItem struct {
+	Type  string
+	bytes []byte
+	items []*StackItem
+}
+
+// ParseStackItem decodes an item whose value is still the raw json value, i.e. not Convert()ed
+func ParseStackItem(raw models.InvokeStack) (*StackItem, error) {
+	item := &StackItem{Type: raw.Type}
+	switch raw.Type {
+	case StackByteArray:
+		s, ok := raw.Value.(string)
+		if !ok && raw.Value != nil {
+			return nil, fmt.Errorf("ByteArray value %v is not a string", raw.Value)
+		}
+		bs, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("ByteArray value %s err: %v", s, err)
+		}
+		item.bytes = bs
+	case StackInteger:
+		n, ok := new(big.Int).SetString(fmt.Sprint(raw.Value), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid Integer value %v", raw.Value)
+		}
+		item.bytes = helper.BigIntToNeoBytes(n)
+	case StackBoolean:
+		var b bool
+		switch v := raw.Value.(type) {
+		case bool:
+			b = v
+		case string:
+			b = strings.EqualFold(v, "true")
+		default:
+			return nil, fmt.Errorf("invalid Boolean value %v", raw.Value)
+		}
+		item.bytes = []byte{}
+		if b {
+			item.bytes = []byte{1}
+		}
+	case StackArray, StackStruct:
+		var values []interface{}
+		switch v := raw.Value.(type) {
+		case []interface{}:
+			values = v
+		case []models.InvokeStack:
+			// already Convert()ed
+			for _, e := range v {
+				values = append(values, map[string]interface{}{"type": e.Type, "value": e.Value})
+			}
+		case nil:
+		default:
+			return nil, fmt.Errorf("invalid %s value %v", raw.Type, raw.Value)
+		}
+		item.items = make([]*StackItem, len(values))
+		for i, v := range values {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s element %d is not an item", raw.Type, i)
+			}
+			t, _ := m["type"].(string)
+			e, err := ParseStackItem(models.InvokeStack{Type: t, Value: m["value"]})
+			if err != nil {
+				return nil, fmt.Errorf("%s element %d: %v", raw.Type, i, err)
+			}
+			item.items[i] = e
+		}
+	case StackInteropInterface:
+	default:
+		return nil, fmt.Errorf("unsupported stack item type %s", raw.Type)
+	}
+	return item, nil
+}
+
+// DeserializeStackItem decodes an item serialized by Neo.Runtime.Serialize, as contracts store
+// arrays. Maps are not supported
+func DeserializeStackItem(data []byte) (*StackItem, error) {
+	item, rest, err := deserializeStackItem(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d bytes left after the item", len(rest))
+	}
+	return item, nil
+}
+
+func deserializeStackItem(data []byte) (*StackItem, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("no item type")
+	}
+	t, data := data[0], data[1:]
+	switch t {
+	case 0x00, 0x01, 0x02:
+		n, data, err := readVarInt(data)
+		if err != nil || uint64(len(data)) < n {
+			return nil, nil, fmt.Errorf("invalid length of a serialized value")
+		}
+		item := &StackItem{Type: []string{StackByteArray, StackBoolean, StackInteger}[t], bytes: data[:n]}
+		return item, data[n:], nil
+	case 0x80, 0x81:
+		n, data, err := readVarInt(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		item := &StackItem{Type: StackArray, items: make([]*StackItem, 0)}
+		if t == 0x81 {
+			item.Type = StackStruct
+		}
+		for i := uint64(0); i < n; i++ {
+			var e *StackItem
+			if e, data, err = deserializeStackItem(data); err != nil {
+				return nil, nil, fmt.Errorf("%s element %d: %v", item.Type, i, err)
+			}
+			item.items = append(item.items, e)
+		}
+		return item, data, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported serialized item type 0x%02x", t)
+}
+
+func readVarInt(data []byte) (uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("no var int")
+	}
+	size := map[byte]int{0xfd: 2, 0xfe: 4, 0xff: 8}[data[0]]
+	if size == 0 {
+		return uint64(data[0]), data[1:], nil
+	}
+	if len(data) < 1+size {
+		return 0, nil, fmt.Errorf("short var int")
+	}
+	var n uint64
+	for i := size; i > 0; i-- {
+		n = n<<8 | uint64(data[i])
+	}
+	return n, data[1+size:], nil
+}
+
+func (this *StackItem) primitive() error {
+	switch this.Type {
+	case StackByteArray, StackInteger, StackBoolean:
+		return nil
+	}
+	return fmt.Errorf("%s item is not a primitive", this.Type)
+}
+
+// Bytes never returns nil for a primitive item, an empty ByteArray gives an empty slice
+func (this *StackItem) Bytes() ([]byte, error) {
+	if err := this.primitive(); err != nil {
+		return nil, err
+	}
+	return append([]byte{}, this.bytes...), nil
+}
+
+// Integer reads the item as a little endian two's complement integer, empty is 0
+func (this *StackItem) Integer() (*big.Int, error) {
+	if err := this.primitive(); err != nil {
+		return nil, err
+	}
+	return helper.BigIntFromNeoBytes(this.bytes), nil
+}
+
+// Bool is true if any byte of the item is not zero
+func (this *StackItem) Bool() (bool, error) {
+	if err := this.primitive(); err != nil {
+		return false, err
+	}
+	for _, b := range this.bytes {
+		if b != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Hash reads a ByteArray as a hash kept as stored, empty for an unset value
+func (this *StackItem) Hash() (NeoHash, error) {
+	if this.Type != StackByteArray {
+		return nil, fmt.Errorf("expect ByteArray hash, got %s", this.Type)
+	}
+	return NeoHash(append([]byte{}, this.bytes...)), nil
+}
+
+// Items returns the elements of an Array or Struct
+func (this *StackItem) Items() ([]*StackItem, error) {
+	if this.Type != StackArray && this.Type != StackStruct {
+		return nil, fmt.Errorf("expect Array, got %s", this.Type)
+	}
+	return this.items, nil
+}
+
+// StackItems decodes the result stack of a script made of calls contract calls, each call
+// leaves exactly one item so a different count means the script or the result is not as expected
+func (this *InvokeResult) StackItems(calls int) ([]*StackItem, error) {
+	if len(this.Stack) != calls {
+		return nil, fmt.Errorf("expect %d stack items, got %d", calls, len(this.Stack))
+	}
+	items := make([]*StackItem, len(this.Stack))
+	for i, raw := range this.Stack {
+		item, err := ParseStackItem(raw)
+		if err != nil {
+			return nil, fmt.Errorf("stack item %d: %v", i, err)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// invokeRead runs a read only script of calls contract calls and decodes its stack
+func (this *NeoInvoker) invokeRead(script []byte, calls int) ([]*StackItem, error) {
+	response, err := this.InvokeScript(script, helper.UInt160{})
+	if err != nil {
+		return nil, err
+	}
+	return response.StackItems(calls)
+}
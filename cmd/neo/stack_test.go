@@ -0,0 +1,87 @@
+package neo
+
+import (
+	"encoding/json"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"testing"
+)
+
+func Test_ParseStackItem(t *testing.T) {
+	stack := make([]models.InvokeStack, 0)
+	err := json.Unmarshal([]byte(`[
+		{"type": "Integer", "value": "-129"},
+		{"type": "ByteArray", "value": ""},
+		{"type": "Boolean", "value": true},
+		{"type": "Array", "value": [{"type": "ByteArray", "value": "e803"}, {"type": "Boolean", "value": "False"}]},
+		{"type": "InteropInterface"}
+	]`), &stack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := (&InvokeResult{InvokeResult: models.InvokeResult{Stack: stack}}).StackItems(len(stack))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := items[0].Integer(); err != nil || n.Int64() != -129 {
+		t.Fatalf("unexpected Integer: %v, %v", n, err)
+	}
+	if n, err := items[1].Integer(); err != nil || n == nil || n.Sign() != 0 {
+		t.Fatalf("empty ByteArray should be 0, got %v, %v", n, err)
+	}
+	if h, err := items[1].Hash(); err != nil || h == nil || !h.IsZero() {
+		t.Fatalf("empty ByteArray should be an empty hash, got %v, %v", h, err)
+	}
+	if b, err := items[2].Bool(); err != nil || !b {
+		t.Fatalf("unexpected Boolean: %v, %v", b, err)
+	}
+	elems, err := items[3].Items()
+	if err != nil || len(elems) != 2 {
+		t.Fatalf("unexpected Array: %v, %v", elems, err)
+	}
+	if n, _ := elems[0].Integer(); n.Int64() != 1000 {
+		t.Fatalf("unexpected Array element: %v", n)
+	}
+	if b, _ := elems[1].Bool(); b {
+		t.Fatal("Boolean False should be false")
+	}
+	if _, err = items[4].Bytes(); err == nil {
+		t.Fatal("InteropInterface should not be read as bytes")
+	}
+	if _, err = items[3].Integer(); err == nil {
+		t.Fatal("Array should not be read as Integer")
+	}
+
+	if _, err = (&InvokeResult{InvokeResult: models.InvokeResult{Stack: stack}}).StackItems(2); err == nil {
+		t.Fatal("stack length should be checked against calls")
+	}
+	if _, err = ParseStackItem(models.InvokeStack{Type: "Integer", Value: "0x10"}); err == nil {
+		t.Fatal("invalid Integer should be rejected")
+	}
+}
+
+func Test_GetAssetBalances(t *testing.T) {
+	invoker := newConfirmInvoker(t, map[string]interface{}{
+		"invokescript": map[string]interface{}{
+			"state":        "HALT",
+			"gas_consumed": "0.3",
+			"stack": []interface{}{
+				map[string]interface{}{"type": "Integer", "value": "100000000"},
+				map[string]interface{}{"type": "ByteArray", "value": ""},
+				map[string]interface{}{"type": "ByteArray", "value": "e803"},
+			},
+		},
+	})
+	asset, _ := ParseNeoAddr(testWrapper)
+	bals, err := invoker.GetAssetBalances(asset, [][]byte{asset, asset, asset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []int64{100000000, 0, 1000} {
+		if bals[i] == nil || bals[i].Int64() != expected {
+			t.Fatalf("balance %d: expect %d, got %v", i, expected, bals[i])
+		}
+	}
+	if _, err = invoker.GetAssetBalances(asset, [][]byte{asset, asset}); err == nil {
+		t.Fatal("mismatched stack length should fail")
+	}
+}
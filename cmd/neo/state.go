@@ -0,0 +1,119 @@
+package neo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"math/big"
+)
+
+// storage keys of the poly wrapper, see NeoWrapper.cs
+const (
+	OwnerKey        = "0101"
+	FeeCollectorKey = "0102"
+	NeoLockProxyKey = "0103"
+	PausedKey       = "0104"
+)
+
+// WrapperStorageKeys are all the keys the poly wrapper stores
+var WrapperStorageKeys = []string{OwnerKey, FeeCollectorKey, NeoLockProxyKey, PausedKey}
+
+// WrapperAsset is an asset to report in WrapperState, bindings are read for ToChainId
+type WrapperAsset struct {
+	Hash      []byte
+	ToChainId uint64
+}
+
+// WrapperState is a snapshot of the poly wrapper, read by WrapperState
+type WrapperState struct {
+	Wrapper      NeoHash              `json:"wrapper"`
+	Owner        NeoHash              `json:"owner"`
+	FeeCollector NeoHash              `json:"feeCollector"`
+	LockProxy    NeoHash              `json:"lockProxy"`
+	Paused       bool                 `json:"paused"`
+	Assets       []*WrapperAssetState `json:"assets"`
+}
+
+// WrapperAssetState is the balance of an asset held by the wrapper, which are the fees not
+// extracted yet, and its lock proxy bindings, empty if unbound or no lock proxy is set
+type WrapperAssetState struct {
+	Asset     NeoHash  `json:"asset"`
+	Balance   *big.Int `json:"balance"`
+	ToChainId uint64   `json:"toChainId"`
+	ToAsset   NeoHash  `json:"toAsset,omitempty"`
+	ToProxy   NeoHash  `json:"toProxy,omitempty"`
+}
+
+// WrapperState reads the lock proxy from the wrapper storage with getstorage, then the state of
+// the poly wrapper and of assets with one invokescript. An entry script can not call a contract
+// whose hash it reads, so the lock proxy whose bindings are read comes from the storage, and is
+// checked against lockProxy() in the script to detect a change between the two reads
+func (this *NeoInvoker) WrapperState(neoPolyWrapper []byte, assets []*WrapperAsset) (*WrapperState, error) {
+	lockProxy, err := this.GetStorage(neoPolyWrapper, NeoLockProxyKey)
+	if err != nil {
+		return nil, fmt.Errorf("[WrapperState], GetStorage lock proxy err: %v", err)
+	}
+	neoLockProxy, err := hex.DecodeString(lockProxy)
+	if err != nil || (len(neoLockProxy) != 0 && len(neoLockProxy) != 20) {
+		return nil, fmt.Errorf("[WrapperState], invalid lock proxy %s in storage", lockProxy)
+	}
+
+	scriptBuilder := sc.NewScriptBuilder()
+	for _, method := range []string{"owner", "feeCollector", "lockProxy", "paused"} {
+		scriptBuilder.MakeInvocationScript(neoPolyWrapper, method, []sc.ContractParameter{})
+	}
+	calls := 4
+	for _, asset := range assets {
+		scriptBuilder.MakeInvocationScript(asset.Hash, "balanceOf", []sc.ContractParameter{
+			{Type: sc.ByteArray, Value: neoPolyWrapper},
+		})
+		calls++
+		if len(neoLockProxy) == 0 {
+			continue
+		}
+		toChainId := sc.ContractParameter{Type: sc.Integer, Value: *new(big.Int).SetUint64(asset.ToChainId)}
+		scriptBuilder.MakeInvocationScript(neoLockProxy, "getAssetHash", []sc.ContractParameter{
+			{Type: sc.ByteArray, Value: asset.Hash},
+			toChainId,
+		})
+		scriptBuilder.MakeInvocationScript(neoLockProxy, "getProxyHash", []sc.ContractParameter{toChainId})
+		calls += 2
+	}
+	script := scriptBuilder.ToArray()
+
+	items, err := this.invokeRead(script, calls)
+	if err != nil {
+		return nil, fmt.Errorf("[WrapperState], invokeRead err: %w", err)
+	}
+	state := &WrapperState{Wrapper: neoPolyWrapper, Assets: make([]*WrapperAssetState, len(assets))}
+	for i, field := range []*NeoHash{&state.Owner, &state.FeeCollector, &state.LockProxy} {
+		if *field, err = items[i].Hash(); err != nil {
+			return nil, fmt.Errorf("[WrapperState], item %d: %v", i, err)
+		}
+	}
+	if !state.LockProxy.Equal(neoLockProxy) {
+		return nil, fmt.Errorf("[WrapperState], lock proxy changed from %s to %s while reading", NeoHash(neoLockProxy).String(), state.LockProxy.String())
+	}
+	if state.Paused, err = items[3].Bool(); err != nil {
+		return nil, fmt.Errorf("[WrapperState], paused: %v", err)
+	}
+	items = items[4:]
+	for i, asset := range assets {
+		s := &WrapperAssetState{Asset: asset.Hash, ToChainId: asset.ToChainId}
+		if s.Balance, err = items[0].Integer(); err != nil {
+			return nil, fmt.Errorf("[WrapperState], balance of asset %x: %v", asset.Hash, err)
+		}
+		items = items[1:]
+		if len(neoLockProxy) != 0 {
+			if s.ToAsset, err = items[0].Hash(); err != nil {
+				return nil, fmt.Errorf("[WrapperState], asset hash of %x: %v", asset.Hash, err)
+			}
+			if s.ToProxy, err = items[1].Hash(); err != nil {
+				return nil, fmt.Errorf("[WrapperState], proxy hash of chain %d: %v", asset.ToChainId, err)
+			}
+			items = items[2:]
+		}
+		state.Assets[i] = s
+	}
+	return state, nil
+}
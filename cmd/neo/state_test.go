@@ -0,0 +1,53 @@
+package neo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_WrapperState(t *testing.T) {
+	wrapper, _ := ParseNeoAddr(testWrapper)
+	lockProxy := NeoHash(wrapper).LittleEndian()
+	hash := map[string]interface{}{"type": "ByteArray", "value": lockProxy}
+	unbound := map[string]interface{}{"type": "ByteArray", "value": ""}
+	results := map[string]interface{}{
+		"getstorage": lockProxy,
+		"invokescript": map[string]interface{}{
+			"state":        "HALT",
+			"gas_consumed": "0.5",
+			"stack": []interface{}{
+				hash, hash, hash,
+				map[string]interface{}{"type": "Boolean", "value": true},
+				map[string]interface{}{"type": "Integer", "value": "1000"}, hash, hash,
+				map[string]interface{}{"type": "ByteArray", "value": ""}, unbound, hash,
+			},
+		},
+	}
+	invoker := newConfirmInvoker(t, results)
+	assets := []*WrapperAsset{{Hash: wrapper, ToChainId: 2}, {Hash: wrapper, ToChainId: 79}}
+	state, err := invoker.WrapperState(wrapper, assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Paused || !state.LockProxy.Equal(wrapper) || len(state.Assets) != 2 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if state.Assets[0].Balance.Int64() != 1000 || !state.Assets[0].ToAsset.Equal(wrapper) || state.Assets[1].Balance.Sign() != 0 || !state.Assets[1].ToAsset.IsZero() {
+		t.Fatalf("unexpected assets: %+v, %+v", state.Assets[0], state.Assets[1])
+	}
+	raw, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &WrapperState{}
+	if err = json.Unmarshal(raw, decoded); err != nil || !strings.Contains(string(raw), `"owner":"`+testWrapper+`"`) || !decoded.Owner.Equal(wrapper) {
+		t.Fatalf("unexpected json %s, err: %v", raw, err)
+	}
+
+	// the lock proxy read by the script must be the one whose bindings were read
+	results["getstorage"] = strings.Repeat("00", 20)
+	if _, err = invoker.WrapperState(wrapper, assets); err == nil {
+		t.Fatal("a lock proxy changed while reading should fail")
+	}
+}
@@ -0,0 +1,148 @@
+package neo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/helper"
+)
+
+// storage keys of the NEO cross chain manager contract
+var CcmcGenesisKey = hex.EncodeToString([]byte("IsInitGenesisBlock"))
+
+const (
+	CcmcEpochHeightKey    = "0201"
+	CcmcConsensusPeersKey = "0204"
+)
+
+// WrapperStorage is the storage of the poly wrapper decoded by InspectWrapperStorage. An unset
+// owner is empty, the contract then falls back to its DefaultOwner
+type WrapperStorage struct {
+	Wrapper      NeoHash `json:"wrapper"`
+	Owner        NeoHash `json:"owner"`
+	FeeCollector NeoHash `json:"feeCollector"`
+	LockProxy    NeoHash `json:"lockProxy"`
+	Paused       bool    `json:"paused"`
+	// Raw is the hex value of each key, empty if unset
+	Raw map[string]string `json:"raw"`
+}
+
+// CcmcStorage is the storage of the NEO cross chain manager decoded by InspectCcmcStorage
+type CcmcStorage struct {
+	Ccmc NeoHash `json:"ccmc"`
+	// Initialized is whether the poly genesis header was synced, at GenesisHeight
+	Initialized   bool   `json:"initialized"`
+	GenesisHeight uint64 `json:"genesisHeight"`
+	// EpochHeight is the poly height the current ConsensusPeers were synced at
+	EpochHeight    uint64            `json:"epochHeight"`
+	ConsensusPeers []string          `json:"consensusPeers"`
+	Raw            map[string]string `json:"raw"`
+}
+
+// StorageReport is the decoded storage of the contracts given to InspectStorage
+type StorageReport struct {
+	Wrapper *WrapperStorage `json:"wrapper,omitempty"`
+	Ccmc    *CcmcStorage    `json:"ccmc,omitempty"`
+}
+
+// InspectStorage decodes the known keys of the poly wrapper and of the cross chain manager, a
+// nil contract is left out of the report
+func (this *NeoInvoker) InspectStorage(neoPolyWrapper []byte, ccmc []byte) (*StorageReport, error) {
+	res := &StorageReport{}
+	var err error
+	if neoPolyWrapper != nil {
+		if res.Wrapper, err = this.InspectWrapperStorage(neoPolyWrapper); err != nil {
+			return nil, err
+		}
+	}
+	if ccmc != nil {
+		if res.Ccmc, err = this.InspectCcmcStorage(ccmc); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (this *NeoInvoker) InspectWrapperStorage(neoPolyWrapper []byte) (*WrapperStorage, error) {
+	raw, err := this.storageOf(neoPolyWrapper, WrapperStorageKeys)
+	if err != nil {
+		return nil, fmt.Errorf("[InspectWrapperStorage], %v", err)
+	}
+	res := &WrapperStorage{Wrapper: neoPolyWrapper, Raw: raw}
+	for key, hash := range map[string]*NeoHash{OwnerKey: &res.Owner, FeeCollectorKey: &res.FeeCollector, NeoLockProxyKey: &res.LockProxy} {
+		if *hash, err = storedHash(raw[key]); err != nil {
+			return nil, fmt.Errorf("[InspectWrapperStorage], key %s: %v", key, err)
+		}
+	}
+	// pause stores 01, unpause deletes the key
+	res.Paused = len(raw[PausedKey]) == 2
+	return res, nil
+}
+
+func (this *NeoInvoker) InspectCcmcStorage(ccmc []byte) (*CcmcStorage, error) {
+	raw, err := this.storageOf(ccmc, []string{CcmcGenesisKey, CcmcEpochHeightKey, CcmcConsensusPeersKey})
+	if err != nil {
+		return nil, fmt.Errorf("[InspectCcmcStorage], %v", err)
+	}
+	res := &CcmcStorage{Ccmc: ccmc, Raw: raw, ConsensusPeers: make([]string, 0)}
+	res.Initialized = raw[CcmcGenesisKey] != ""
+	if res.GenesisHeight, err = storedHeight(raw[CcmcGenesisKey]); err != nil {
+		return nil, fmt.Errorf("[InspectCcmcStorage], genesis height: %v", err)
+	}
+	if res.EpochHeight, err = storedHeight(raw[CcmcEpochHeightKey]); err != nil {
+		return nil, fmt.Errorf("[InspectCcmcStorage], epoch height: %v", err)
+	}
+	if raw[CcmcConsensusPeersKey] == "" {
+		return res, nil
+	}
+	value, _ := hex.DecodeString(raw[CcmcConsensusPeersKey])
+	peers, err := DeserializeStackItem(value)
+	if err != nil {
+		return nil, fmt.Errorf("[InspectCcmcStorage], consensus peers: %v", err)
+	}
+	items, err := peers.Items()
+	if err != nil {
+		return nil, fmt.Errorf("[InspectCcmcStorage], consensus peers: %v", err)
+	}
+	for i, item := range items {
+		peer, err := item.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("[InspectCcmcStorage], consensus peer %d: %v", i, err)
+		}
+		res.ConsensusPeers = append(res.ConsensusPeers, hex.EncodeToString(peer))
+	}
+	return res, nil
+}
+
+// storageOf reads keys of contract, the hex values of unset keys are empty
+func (this *NeoInvoker) storageOf(contract []byte, keys []string) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, key := range keys {
+		value, err := this.GetStorage(contract, key)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = hex.DecodeString(value); err != nil {
+			return nil, fmt.Errorf("key %s value %s is not hex", key, value)
+		}
+		res[key] = value
+	}
+	return res, nil
+}
+
+func storedHash(value string) (NeoHash, error) {
+	b, _ := hex.DecodeString(value)
+	if len(b) != 0 && len(b) != 20 {
+		return nil, fmt.Errorf("%s is not a hash", value)
+	}
+	return NeoHash(b), nil
+}
+
+// storedHeight decodes a height stored as a NEO VM integer
+func storedHeight(value string) (uint64, error) {
+	b, _ := hex.DecodeString(value)
+	n := helper.BigIntFromNeoBytes(b)
+	if n.Sign() < 0 || !n.IsUint64() {
+		return 0, fmt.Errorf("%s is not a height", value)
+	}
+	return n.Uint64(), nil
+}
@@ -0,0 +1,83 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func Test_DeserializeStackItem(t *testing.T) {
+	peer := bytes.Repeat([]byte{0xaa}, 20)
+	data := append([]byte{0x80, 0x02, 0x00, 0x14}, peer...)
+	data = append(data, 0x02, 0x02, 0xe8, 0x03)
+	item, err := DeserializeStackItem(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, _ := item.Items()
+	if len(items) != 2 {
+		t.Fatalf("expect 2 elements, got %d", len(items))
+	}
+	if b, _ := items[0].Bytes(); !bytes.Equal(b, peer) {
+		t.Fatalf("unexpected element 0: %x", b)
+	}
+	if n, _ := items[1].Integer(); n.Int64() != 1000 {
+		t.Fatalf("unexpected element 1: %v", n)
+	}
+	if _, err = DeserializeStackItem(data[:len(data)-1]); err == nil {
+		t.Fatal("a truncated item should fail")
+	}
+	if _, err = DeserializeStackItem([]byte{0x82, 0x00}); err == nil {
+		t.Fatal("a map should fail")
+	}
+}
+
+func Test_InspectStorage(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	wrapper, _ := ParseNeoHash("0xb88424b36a5548be2448682fcab53f49596f0dff")
+	ccmc, _ := ParseNeoHash("0xe1695b1314a1331e3935481620417ed835669407")
+	lockProxy, _ := ParseNeoHash(testLockHash)
+	owner, _ := ParseNeoAddr(acc.Address)
+	fake.setStorage(wrapper, []byte{0x01, 0x01}, owner)
+	fake.setStorage(wrapper, []byte{0x01, 0x03}, lockProxy)
+	fake.setStorage(wrapper, []byte{0x01, 0x04}, []byte{0x01})
+	peers := append([]byte{0x80, 0x02, 0x00, 0x14}, bytes.Repeat([]byte{0x01}, 20)...)
+	peers = append(peers, append([]byte{0x00, 0x14}, bytes.Repeat([]byte{0x02}, 20)...)...)
+	fake.setStorage(ccmc, []byte("IsInitGenesisBlock"), helper.BigIntToNeoBytes(big.NewInt(1000)))
+	fake.setStorage(ccmc, []byte{0x02, 0x01}, helper.BigIntToNeoBytes(big.NewInt(60000)))
+	fake.setStorage(ccmc, []byte{0x02, 0x04}, peers)
+
+	res, err := invoker.InspectStorage(wrapper, ccmc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := res.Wrapper
+	if w.Owner.Address() != acc.Address || !w.LockProxy.Equal(lockProxy) || len(w.FeeCollector) != 0 || !w.Paused || w.Raw[PausedKey] != "01" {
+		t.Fatalf("unexpected wrapper storage: %+v", w)
+	}
+	c := res.Ccmc
+	if !c.Initialized || c.GenesisHeight != 1000 || c.EpochHeight != 60000 || len(c.ConsensusPeers) != 2 || c.ConsensusPeers[1] != strings.Repeat("02", 20) {
+		t.Fatalf("unexpected ccmc storage: %+v", c)
+	}
+	out, err := json.Marshal(res)
+	if err != nil || !strings.Contains(string(out), `"lockProxy":"`+testLockHash+`"`) || !strings.Contains(string(out), `"epochHeight":60000`) {
+		t.Fatalf("unexpected report %s, err: %v", out, err)
+	}
+
+	// a value of the wrong size is reported
+	fake.setStorage(wrapper, []byte{0x01, 0x02}, []byte{0x01, 0x02})
+	if _, err = invoker.InspectStorage(wrapper, nil); err == nil || !strings.Contains(err.Error(), "0102") {
+		t.Fatalf("expect an invalid fee collector, got %v", err)
+	}
+	// nothing stored, the ccmc is not initialised
+	empty, _ := ParseNeoHash(testNNeo)
+	if res, err = invoker.InspectStorage(nil, empty); err != nil || res.Wrapper != nil || res.Ccmc.Initialized || len(res.Ccmc.ConsensusPeers) != 0 {
+		t.Fatalf("unexpected report of an empty ccmc: %+v, err: %v", res, err)
+	}
+}
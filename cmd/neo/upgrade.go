@@ -0,0 +1,219 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/joeqian10/neo-gogogo/rpc/models"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"github.com/skyinglyh1/poly_wrapper/log"
+	"io/ioutil"
+	"strings"
+)
+
+// StorageChange is a key of the poly wrapper before and after an upgrade, values in hex
+type StorageChange struct {
+	Key    string `json:"key"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// NeoUpgrade is the record of UpgradeWrapper
+type NeoUpgrade struct {
+	Old     NeoHash          `json:"old"`
+	New     NeoHash          `json:"new"`
+	TxHash  string           `json:"txHash"`
+	Before  *WrapperState    `json:"before"`
+	After   *WrapperState    `json:"after"`
+	Storage []*StorageChange `json:"storage"`
+}
+
+// Print logs the state and the storage of the wrapper before and after the upgrade
+func (this *NeoUpgrade) Print() {
+	log.Infof("neo poly wrapper upgrade %s -> %s, txHash: %s", this.Old.String(), this.New.String(), this.TxHash)
+	log.Infof("  %-13s %-42s %-42s", "", "before", "after")
+	log.Infof("  %-13s %-42s %-42s", "owner", this.Before.Owner.Address(), this.After.Owner.Address())
+	log.Infof("  %-13s %-42s %-42s", "feeCollector", this.Before.FeeCollector.Address(), this.After.FeeCollector.Address())
+	log.Infof("  %-13s %-42s %-42s", "lockProxy", this.Before.LockProxy.String(), this.After.LockProxy.String())
+	log.Infof("  %-13s %-42t %-42t", "paused", this.Before.Paused, this.After.Paused)
+	for _, c := range this.Storage {
+		log.Infof("  storage %-5s %-42s %-42s", c.Key, orNone(c.Before), orNone(c.After))
+	}
+}
+
+// migrateOperation is the push of "migrate" which Main compares the method against
+var migrateOperation = append([]byte{7}, "migrate"...)
+
+// names of the parameter types in getcontractstate
+var paramTypeNames = map[byte]string{
+	byte(sc.Signature): "Signature", byte(sc.Boolean): "Boolean", byte(sc.Integer): "Integer",
+	byte(sc.Hash160): "Hash160", byte(sc.Hash256): "Hash256", byte(sc.ByteArray): "ByteArray",
+	byte(sc.PublicKey): "PublicKey", byte(sc.String): "String", byte(sc.Array): "Array",
+	byte(sc.Map): "Map", byte(sc.InteropInterface): "InteropInterface", byte(sc.Void): "Void",
+}
+
+// NeoAbi is the abi.json the NEO compiler writes next to an avm
+type NeoAbi struct {
+	Hash      string `json:"hash"`
+	Functions []struct {
+		Name string `json:"name"`
+	} `json:"functions"`
+}
+
+func ReadNeoAbi(path string) (*NeoAbi, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read abi %s err: %v", path, err)
+	}
+	res := &NeoAbi{}
+	if err = json.Unmarshal(raw, res); err != nil {
+		return nil, fmt.Errorf("unmarshal abi %s err: %v", path, err)
+	}
+	return res, nil
+}
+
+// Exports tells if the contract has a function name
+func (this *NeoAbi) Exports(name string) bool {
+	for _, f := range this.Functions {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMigratable fails unless the contract of state has the migrate entry and takes and returns
+// the types of meta
+func checkMigratable(state *models.RpcContractState, meta *config.NeoContractMeta) error {
+	script, err := hex.DecodeString(state.Script)
+	if err != nil {
+		return fmt.Errorf("script of %s: %v", state.Hash, err)
+	}
+	if !bytes.Contains(script, migrateOperation) {
+		return fmt.Errorf("%s has no migrate entry, it was deployed from an avm without it", state.Hash)
+	}
+	paramTypes, err := hex.DecodeString(meta.ParamTypes)
+	if err != nil {
+		return fmt.Errorf("paramTypes %s: %v", meta.ParamTypes, err)
+	}
+	returnType, err := hex.DecodeString(meta.ReturnType)
+	if err != nil || len(returnType) != 1 {
+		return fmt.Errorf("returnType %s is not one hex byte", meta.ReturnType)
+	}
+	names := make([]string, len(paramTypes))
+	for i, t := range paramTypes {
+		names[i] = paramTypeNames[t]
+	}
+	if strings.Join(names, ",") != strings.Join(state.Parameters, ",") || paramTypeNames[returnType[0]] != state.ReturnType {
+		return fmt.Errorf("%s takes (%s) and returns %s, the new contract (%s) and %s", state.Hash,
+			strings.Join(state.Parameters, ","), state.ReturnType, strings.Join(names, ","), paramTypeNames[returnType[0]])
+	}
+	return nil
+}
+
+// MigrateScript builds the call of the migrate entry of the poly wrapper, which runs
+// Neo.Contract.Migrate with code and meta
+func MigrateScript(neoPolyWrapper []byte, code []byte, meta *config.NeoContractMeta) ([]byte, error) {
+	args, err := contractArgs(code, meta)
+	if err != nil {
+		return nil, err
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "migrate", args)
+	return scriptBuilder.ToArray(), nil
+}
+
+// UpgradeWrapper migrates the poly wrapper to code, whose compiler abi is abi. Only a wrapper
+// deployed from an avm with the migrate entry can be upgraded. src/neo/neo_wrapper.avm has none,
+// so a NeoWrapper.cs with a migrate entry calling Neo.Contract.Migrate has to be compiled and
+// deployed first, wrappers of the shipped avm have to be deployed again. The new code must export
+// migrate as well, so it can be upgraded in turn, and
+// take and return the types of the old one. Neo.Contract.Migrate creates the new contract and
+// only copies the storage if it does not exist yet, so the new code must not be deployed before.
+// The old state is read first, and the new contract must report the same owner, fee collector,
+// lock proxy, paused flag and stored keys
+func (this *NeoInvoker) UpgradeWrapper(neoPolyWrapper []byte, code []byte, abi *NeoAbi, meta *config.NeoContractMeta) (*NeoUpgrade, error) {
+	newHash, err := ContractHash(code)
+	if err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], ContractHash err: %v", err)
+	}
+	if newHash.Equal(neoPolyWrapper) {
+		return nil, fmt.Errorf("[UpgradeWrapper], %s runs this code already", newHash.String())
+	}
+	if abi == nil || !strings.EqualFold(abi.Hash, newHash.String()) {
+		return nil, fmt.Errorf("[UpgradeWrapper], the abi is not the one of %s", newHash.String())
+	}
+	if !abi.Exports("migrate") || !bytes.Contains(code, migrateOperation) {
+		return nil, fmt.Errorf("[UpgradeWrapper], the new code does not export migrate, it could not be upgraded again")
+	}
+	if meta == nil || !meta.HasStorage {
+		return nil, fmt.Errorf("[UpgradeWrapper], the new contract needs storage to keep the state")
+	}
+	old, err := this.ContractState(neoPolyWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %v", err)
+	}
+	if old == nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], no poly wrapper %s", NeoHash(neoPolyWrapper).String())
+	}
+	if err = checkMigratable(old, meta); err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %v", err)
+	}
+	exists, err := this.ContractExists(newHash)
+	if err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("[UpgradeWrapper], %s is deployed already, migrate would not copy the storage to it", newHash.String())
+	}
+	script, err := MigrateScript(neoPolyWrapper, code, meta)
+	if err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %v", err)
+	}
+	res := &NeoUpgrade{Old: neoPolyWrapper, New: newHash}
+	if res.Before, err = this.WrapperState(neoPolyWrapper, nil); err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %w", err)
+	}
+	before, err := this.storageOf(neoPolyWrapper, WrapperStorageKeys)
+	if err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %w", err)
+	}
+
+	if res.TxHash, err = this.sendAsOwner(neoPolyWrapper, "migrate", script); err != nil {
+		return nil, fmt.Errorf("[UpgradeWrapper], %w", err)
+	}
+	if exists, err = this.ContractExists(newHash); err != nil || !exists {
+		return res, fmt.Errorf("[UpgradeWrapper], new contract %s not found after migrate, err: %v", newHash.String(), err)
+	}
+	if res.After, err = this.WrapperState(newHash, nil); err != nil {
+		return res, fmt.Errorf("[UpgradeWrapper], %w", err)
+	}
+	after, err := this.storageOf(newHash, WrapperStorageKeys)
+	if err != nil {
+		return res, fmt.Errorf("[UpgradeWrapper], %w", err)
+	}
+	res.Storage = make([]*StorageChange, len(WrapperStorageKeys))
+	for i, key := range WrapperStorageKeys {
+		res.Storage[i] = &StorageChange{Key: key, Before: before[key], After: after[key]}
+	}
+	res.Print()
+
+	switch {
+	case !res.After.Owner.Equal(res.Before.Owner):
+		return res, fmt.Errorf("[UpgradeWrapper], owner changed from %s to %s", res.Before.Owner.Address(), res.After.Owner.Address())
+	case !res.After.FeeCollector.Equal(res.Before.FeeCollector):
+		return res, fmt.Errorf("[UpgradeWrapper], fee collector changed from %s to %s", res.Before.FeeCollector.Address(), res.After.FeeCollector.Address())
+	case !res.After.LockProxy.Equal(res.Before.LockProxy):
+		return res, fmt.Errorf("[UpgradeWrapper], lock proxy changed from %s to %s", res.Before.LockProxy.String(), res.After.LockProxy.String())
+	case res.After.Paused != res.Before.Paused:
+		return res, fmt.Errorf("[UpgradeWrapper], paused changed from %t to %t", res.Before.Paused, res.After.Paused)
+	}
+	for _, c := range res.Storage {
+		if c.Before != c.After {
+			return res, fmt.Errorf("[UpgradeWrapper], storage %s changed from %s to %s", c.Key, orNone(c.Before), orNone(c.After))
+		}
+	}
+	return res, nil
+}
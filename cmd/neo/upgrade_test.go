@@ -0,0 +1,106 @@
+package neo
+
+import (
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"strings"
+	"testing"
+)
+
+func Test_UpgradeWrapper(t *testing.T) {
+	meta := &config.NeoContractMeta{ParamTypes: "0710", ReturnType: "05", HasStorage: true, HasDynamicInvoke: true, Name: "neoPolyWrapper", Version: "2.0"}
+	// both wrappers compare the method against "migrate"
+	oldCode := append([]byte{0x51}, migrateOperation...)
+	newCode := append([]byte{0x52}, migrateOperation...)
+	old, _ := ContractHash(oldCode)
+	newHash, _ := ContractHash(newCode)
+	abiOf := func(code []byte, functions ...string) *NeoAbi {
+		hash, _ := ContractHash(code)
+		abi := &NeoAbi{Hash: hash.String()}
+		for _, f := range functions {
+			abi.Functions = append(abi.Functions, struct {
+				Name string `json:"name"`
+			}{f})
+		}
+		return abi
+	}
+	lockProxy, _ := ParseNeoHash(testLockHash)
+	acc, _ := wallet.NewAccount()
+	owner, _ := ParseNeoAddr(acc.Address)
+	collector, _ := wallet.NewAccount()
+	feeCollector, _ := ParseNeoAddr(collector.Address)
+
+	fake := newFakeNeoRpc(t)
+	invoker := fake.invoker(acc)
+	fake.Contracts[old.BigEndian()] = oldCode
+	fake.Reads["owner"] = hashItem(owner)
+	fake.Reads["feeCollector"] = hashItem(feeCollector)
+	fake.Reads["lockProxy"] = hashItem(lockProxy)
+	fake.setStorage(old, []byte{0x01, 0x01}, owner)
+	fake.setStorage(old, []byte{0x01, 0x02}, feeCollector)
+	fake.setStorage(old, []byte{0x01, 0x03}, lockProxy)
+	// migrate moves the storage to the new contract, dropping the keys of skip
+	skip := ""
+	fake.OnSent = func(calls []*ContractCall) {
+		if calls[0].Method != "migrate" {
+			return
+		}
+		created, _ := ContractHash(calls[0].Args[0].Bytes)
+		fake.Contracts[created.BigEndian()] = calls[0].Args[0].Bytes
+		for k, v := range fake.Storage {
+			from := strings.TrimPrefix(old.BigEndian(), "0x")
+			if strings.HasPrefix(k, from) && k[len(from):] != skip {
+				fake.Storage[strings.TrimPrefix(created.BigEndian(), "0x")+k[len(from):]] = v
+			}
+		}
+	}
+
+	// the new code must export migrate and take the types of the old one, the old one must have migrate
+	legacyCode := []byte{0x51, 0x66}
+	legacy, _ := ContractHash(legacyCode)
+	fake.Contracts[legacy.BigEndian()] = legacyCode
+	for _, c := range []struct {
+		wrapper NeoHash
+		code    []byte
+		abi     *NeoAbi
+		meta    *config.NeoContractMeta
+		err     string
+	}{
+		{old, newCode, abiOf(oldCode, "Main", "migrate"), meta, "abi is not the one"},
+		{old, newCode, abiOf(newCode, "Main", "lock"), meta, "does not export migrate"},
+		{old, []byte{0x52, 0x66}, abiOf([]byte{0x52, 0x66}, "Main", "migrate"), meta, "does not export migrate"},
+		{legacy, newCode, abiOf(newCode, "Main", "migrate"), meta, "has no migrate entry"},
+		{old, newCode, abiOf(newCode, "Main", "migrate"), &config.NeoContractMeta{ParamTypes: "0705", ReturnType: "05", HasStorage: true, Name: "neoPolyWrapper", Version: "2.0"}, "takes (String,Array)"},
+	} {
+		if _, err := invoker.UpgradeWrapper(c.wrapper, c.code, c.abi, c.meta); err == nil || !strings.Contains(err.Error(), c.err) || len(fake.Sent) != 0 {
+			t.Fatalf("expect %q before migrate, got %v, %d txs", c.err, err, len(fake.Sent))
+		}
+	}
+
+	res, err := invoker.UpgradeWrapper(old, newCode, abiOf(newCode, "Main", "migrate"), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := fake.sentCalls(0)
+	if len(fake.Sent) != 1 || calls[0].Method != "migrate" || string(calls[0].Args[0].Bytes) != string(newCode) || string(calls[0].Args[5].Bytes) != "2.0" {
+		t.Fatalf("unexpected migrate calls: %+v", calls)
+	}
+	if !res.New.Equal(newHash) || res.TxHash == "" || !res.After.LockProxy.Equal(lockProxy) || !res.After.Owner.Equal(owner) {
+		t.Fatalf("unexpected upgrade: %+v", res)
+	}
+	if len(res.Storage) != 4 || res.Storage[1].After != NeoHash(feeCollector).LittleEndian() || res.Storage[3].After != "" {
+		t.Fatalf("unexpected storage: %+v", res.Storage)
+	}
+
+	// the new contract exists now, migrating to it again would not copy the storage
+	if _, err = invoker.UpgradeWrapper(old, newCode, abiOf(newCode, "Main", "migrate"), meta); err == nil || len(fake.Sent) != 1 {
+		t.Fatalf("expect an error for a deployed contract, got %v, %d txs", err, len(fake.Sent))
+	}
+
+	// a key lost on the way is reported
+	skip = FeeCollectorKey
+	otherCode := append([]byte{0x53}, migrateOperation...)
+	if _, err = invoker.UpgradeWrapper(old, otherCode, abiOf(otherCode, "Main", "migrate"), meta); err == nil || !strings.Contains(err.Error(), "storage 0102 changed") {
+		t.Fatalf("expect the lost fee collector to be reported, got %v", err)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"github.com/joeqian10/neo-gogogo/wallet"
 	"github.com/ontio/ontology/common"
-	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
 	"strings"
 )
 
@@ -25,26 +25,23 @@ func ParseNeoAddr(s string) ([]byte, error) {
 	}
 	return hex.DecodeString(s)
 }
-func GetAccountByPassword(walletPath, pwd string) *wallet.Account {
-	// open the NEO wallet
-	w, err := wallet.NewWalletFromFile(walletPath) //
-	if err != nil {
-		log.Errorf("[GetAccountByPassword] Failed to open NEO wallet")
-		return nil
-	}
 
-	if pwd == "" {
-		log.Errorf("pls provide neo wallet pwd")
-		return nil
-	}
-	err = w.DecryptAll(pwd)
+// GetAccountByPassword returns the first account of a NEP-6 wallet
+func GetAccountByPassword(walletPath, pwd string) (*wallet.Account, error) {
+	return GetAccount(walletPath, pwd, "")
+}
+
+// GetAccount returns the account of a NEP-6 wallet whose address or label is account, or the first
+// one if account is empty, see signer.LoadNep6Account
+func GetAccount(walletPath, pwd, account string) (*wallet.Account, error) {
+	return signer.LoadNep6Account(walletPath, pwd, account)
+}
+
+// GetAccountByWIF returns the account of a WIF private key
+func GetAccountByWIF(wif string) (*wallet.Account, error) {
+	acc, err := wallet.NewAccountFromWIF(wif)
 	if err != nil {
-		log.Errorf("[GetAccountByPassword] Failed to decrypt NEO account")
-		return nil
-	}
-	if len(w.Accounts) == 0 {
-		log.Errorf("[GetAccountByPassword] empty account")
-		return nil
+		return nil, fmt.Errorf("[GetAccountByWIF] NewAccountFromWIF err: %v", err)
 	}
-	return w.Accounts[0]
+	return acc, nil
 }
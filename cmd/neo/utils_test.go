@@ -0,0 +1,77 @@
+package neo
+
+import (
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/skyinglyh1/poly_wrapper/config"
+	"path/filepath"
+	"testing"
+)
+
+// newNep2Account returns an account NEP-2 can encrypt, keys.NEP2Encrypt panics on the private
+// keys of less than 32 bytes which wallet.NewAccount returns now and then
+func newNep2Account(t *testing.T) *wallet.Account {
+	for {
+		acc, err := wallet.NewAccount()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(acc.KeyPair.PrivateKey) == 32 {
+			return acc
+		}
+	}
+}
+
+func Test_GetAccount(t *testing.T) {
+	w := wallet.NewWallet()
+	first := newNep2Account(t)
+	operator := newNep2Account(t)
+	operator.Label = "operator"
+	if err := first.Encrypt("1"); err != nil {
+		t.Fatal(err)
+	}
+	// accounts of a shared wallet may use different passwords
+	if err := operator.Encrypt("2"); err != nil {
+		t.Fatal(err)
+	}
+	w.AddAccount(first)
+	w.AddAccount(operator)
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	if err := w.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	acc, err := GetAccountByPassword(path, "1")
+	if err != nil || acc.Address != first.Address {
+		t.Fatalf("expect the first account, got %v, %v", acc, err)
+	}
+	for _, selector := range []string{"operator", operator.Address} {
+		acc, err = GetAccount(path, "2", selector)
+		if err != nil || acc.Address != operator.Address || acc.KeyPair == nil {
+			t.Fatalf("select %s: unexpected account %v, %v", selector, acc, err)
+		}
+	}
+	if _, err = GetAccount(path, "2", "nobody"); err == nil {
+		t.Fatal("unknown account should fail")
+	}
+	if _, err = GetAccount(path, "1", "operator"); err == nil {
+		t.Fatal("wrong password should fail")
+	}
+	if _, err = GetAccount(filepath.Join(t.TempDir(), "missing.json"), "1", ""); err == nil {
+		t.Fatal("missing wallet should fail")
+	}
+
+	invoker, err := NewNeoInvokerFromConfig(&config.TestConfig{
+		NeoUrl:         "http://127.0.0.1:20332",
+		NeoWif:         operator.KeyPair.ExportWIF(),
+		NeoFeePriority: FeePriorityHigh,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoker.Acc.Address != operator.Address || invoker.FeePolicy.Priority != FeePriorityHigh {
+		t.Fatalf("unexpected invoker account %s, fee policy %+v", invoker.Acc.Address, invoker.FeePolicy)
+	}
+	if invoker, err = NewNeoInvokerFromConfig(&config.TestConfig{NeoWallet: path, NeoWalletPwd: "2", NeoAccount: "operator"}); err != nil || invoker.Acc.Address != operator.Address {
+		t.Fatalf("unexpected invoker from wallet: %v", err)
+	}
+}
@@ -4,100 +4,198 @@ import (
 	"fmt"
 	"github.com/joeqian10/neo-gogogo/helper"
 	"github.com/joeqian10/neo-gogogo/sc"
-	"github.com/joeqian10/neo-gogogo/tx"
-	"github.com/joeqian10/neo-gogogo/wallet"
 	"github.com/skyinglyh1/poly_wrapper/log"
+	"github.com/skyinglyh1/poly_wrapper/signer"
 	"math/big"
 )
 
-func (this *NeoInvoker) Deploy(code []byte) {
-	txBuilder := tx.NewTransactionBuilder(this.Cli.Endpoint.String())
-	wh := wallet.NewWalletHelper(txBuilder, this.Acc)
-	ContractHash, err := wh.DeployContract(code, "0710", "05", true, true, true,
-		"neoPolyWrapper", "1.0", "poly", "email", "desc")
+func (this *NeoInvoker) LockProxy(neoPolyWrapper []byte) (NeoHash, error) {
+	scriptBuilder := sc.NewScriptBuilder()
+	args := []sc.ContractParameter{}
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "lockProxy", args)
+	script := scriptBuilder.ToArray()
+
+	items, err := this.invokeRead(script, 1)
 	if err != nil {
-		log.Errorf("DeployContract err: %v", err)
-		return
+		return nil, fmt.Errorf("[LockProxy], invokeRead err: %w", err)
 	}
-	log.Infof("Neo Deploy poly wrapper, contract Hash: %s", ContractHash.String())
-
+	res, err := items[0].Hash()
+	if err != nil {
+		return nil, fmt.Errorf("[LockProxy], %v", err)
+	}
+	return res, nil
 }
 
-func (this *NeoInvoker) LockProxy(neoLockProxy []byte) (string, error) {
+func (this *NeoInvoker) Owner(neoPolyWrapper []byte) (NeoHash, error) {
 	scriptBuilder := sc.NewScriptBuilder()
 	args := []sc.ContractParameter{}
-	scriptBuilder.MakeInvocationScript(neoLockProxy, "lockProxy", args)
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "owner", args)
 	script := scriptBuilder.ToArray()
 
-	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return "", fmt.Errorf("[LockProxy], InvokeScript err: %v", response.Error)
+	items, err := this.invokeRead(script, 1)
+	if err != nil {
+		return nil, fmt.Errorf("[Owner], invokeRead err: %w", err)
 	}
-	for _, stack := range response.Result.Stack {
-		stack.Convert()
-
-		if stack.Type == "ByteArray" {
-			return stack.Value.(string), nil // little endian
-		}
+	res, err := items[0].Hash()
+	if err != nil {
+		return nil, fmt.Errorf("[Owner], %v", err)
 	}
-	return "", fmt.Errorf("lockproxy not found")
+	return res, nil
 }
 
-func (this *NeoInvoker) Owner(neoLockProxy []byte) (string, error) {
+func (this *NeoInvoker) FeeCollector(neoPolyWrapper []byte) (NeoHash, error) {
 	scriptBuilder := sc.NewScriptBuilder()
 	args := []sc.ContractParameter{}
-	scriptBuilder.MakeInvocationScript(neoLockProxy, "owner", args)
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "feeCollector", args)
 	script := scriptBuilder.ToArray()
 
+	items, err := this.invokeRead(script, 1)
+	if err != nil {
+		return nil, fmt.Errorf("[FeeCollector], invokeRead err: %w", err)
+	}
+	res, err := items[0].Hash()
+	if err != nil {
+		return nil, fmt.Errorf("[FeeCollector], %v", err)
+	}
+	return res, nil
+}
+
+func (this *NeoInvoker) Lock(neoPolyWrapper []byte, fromAssetHash []byte, toChainId uint64, toAddress []byte, amount *big.Int, fee *big.Int, id *big.Int) (string, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return "", fmt.Errorf("[LockFromWrapper], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	fromUint160, err := helper.UInt160FromBytes(from)
+	if err != nil {
+		return "", fmt.Errorf("[LockFromWrapper], Uint160FromBytes err: %v", err)
+	}
+	script := LockScript(neoPolyWrapper, fromAssetHash, from, toChainId, toAddress, amount, fee, id)
+
 	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return "", fmt.Errorf("[Owner], InvokeScript err: %v", response.Error)
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
+	if err != nil {
+		return "", fmt.Errorf("[LockFromWrapper] MakeInvocationTx error: %w", err)
+	}
+	// sign transaction
+	err = signer.SignNeoTx(itx, this.Signer)
+	if err != nil {
+		return "", fmt.Errorf("[LockFromWrapper] SignNeoTx error: %s", err)
 	}
-	for _, stack := range response.Result.Stack {
-		stack.Convert()
 
-		if stack.Type == "ByteArray" {
-			return stack.Value.(string), nil // little endian
-		}
+	rawTxString := itx.RawTransactionString()
+
+	// send the raw transaction
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return "", fmt.Errorf("[LockFromWrapper] SendRawTransaction error: %s,  RawTransactionString: %s",
+			err, rawTxString)
 	}
-	return "", fmt.Errorf("Owner not found")
+	log.Infof("Neo LockFromWrapper, txHash: %s", itx.HashString())
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return "", fmt.Errorf("[LockFromWrapper] waitTx error: %w", err)
+	}
+
+	return itx.HashString(), nil
 }
 
-func (this *NeoInvoker) FeeCollector(neoLockProxy []byte) (string, error) {
-	scriptBuilder := sc.NewScriptBuilder()
-	args := []sc.ContractParameter{}
-	scriptBuilder.MakeInvocationScript(neoLockProxy, "feeCollector", args)
-	script := scriptBuilder.ToArray()
+// SpeedUp pays an extra fee for a lock tx already sent through the poly wrapper, txHash of the
+// original lock tx can be given in big endian (as returned by Lock) or little endian
+func (this *NeoInvoker) SpeedUp(neoPolyWrapper []byte, fromAssetHash []byte, txHash string, fee *big.Int) (string, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
+	if err != nil {
+		return "", fmt.Errorf("[SpeedUp], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
+	}
+	fromUint160, err := helper.UInt160FromBytes(from)
+	if err != nil {
+		return "", fmt.Errorf("[SpeedUp], Uint160FromBytes err: %v", err)
+	}
+	lockTxHash, err := this.findLockTxHash(txHash)
+	if err != nil {
+		return "", fmt.Errorf("[SpeedUp], findLockTxHash err: %v", err)
+	}
+	script := SpeedUpScript(neoPolyWrapper, fromAssetHash, from, lockTxHash.Bytes(), fee)
 
 	// create an InvocationTransaction
-	response := this.Cli.InvokeScript(helper.BytesToHex(script), "0000000000000000000000000000000000000000")
-	if response.HasError() || response.Result.State == "FAULT" {
-		log.Errorf("invoke script error: %s", response.Error.Message)
-		return "", fmt.Errorf("[FeeCollector], InvokeScript err: %v", response.Error)
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
+	if err != nil {
+		return "", fmt.Errorf("[SpeedUp] MakeInvocationTx error: %w", err)
+	}
+	// sign transaction
+	err = signer.SignNeoTx(itx, this.Signer)
+	if err != nil {
+		return "", fmt.Errorf("[SpeedUp] SignNeoTx error: %s", err)
 	}
-	for _, stack := range response.Result.Stack {
-		stack.Convert()
 
-		if stack.Type == "ByteArray" {
-			return stack.Value.(string), nil // little endian
+	rawTxString := itx.RawTransactionString()
+
+	// send the raw transaction
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return "", fmt.Errorf("[SpeedUp] SendRawTransaction error: %s,  RawTransactionString: %s",
+			err, rawTxString)
+	}
+	log.Infof("Neo SpeedUp, lockTxHash: %s, txHash: %s", lockTxHash.String(), itx.HashString())
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return "", fmt.Errorf("[SpeedUp] waitTx error: %w", err)
+	}
+
+	return itx.HashString(), nil
+}
+
+// findLockTxHash parses txHash as big endian first and falls back to little endian,
+// the byte order which is known by the chain wins
+func (this *NeoInvoker) findLockTxHash(txHash string) (helper.UInt256, error) {
+	hash, err := helper.UInt256FromString(txHash)
+	if err != nil {
+		return hash, fmt.Errorf("txHash: %s, UInt256FromString err: %v", txHash, err)
+	}
+	reversed, _ := helper.UInt256FromBytes(helper.ReverseBytes(hash.Bytes()))
+	for _, h := range []helper.UInt256{hash, reversed} {
+		res := this.Cli.GetTransactionHeight(h.String())
+		if !res.HasError() && res.Result > 0 {
+			return h, nil
 		}
 	}
-	return "", fmt.Errorf("FeeCollector not found")
+	return hash, fmt.Errorf("tx %s not found on chain in either byte order", txHash)
 }
 
-func (this *NeoInvoker) Lock(neoPolyWrapper []byte, fromAssetHash []byte, toChainId uint64, toAddress []byte, amount *big.Int, fee *big.Int, id *big.Int) (string, error) {
-	from, err := ParseNeoAddr(this.Acc.Address)
+func (this *NeoInvoker) ExtractFee(neoPolyWrapper []byte, token []byte) (string, error) {
+	from, err := ParseNeoAddr(this.Signer.Address())
 	if err != nil {
-		return "", fmt.Errorf("[LockFromWrapper], ParseNeoAddr acct: %s,  err: %v", this.Acc.Address, err)
+		return "", fmt.Errorf("[ExtractFee], ParseNeoAddr acct: %s,  err: %v", this.Signer.Address(), err)
 	}
 	fromUint160, err := helper.UInt160FromBytes(from)
 	if err != nil {
-		return "", fmt.Errorf("[LockFromWrapper], Uint160FromBytes err: %v", err)
+		return "", fmt.Errorf("[ExtractFee], Uint160FromBytes err: %v", err)
+	}
+	script := ExtractFeeScript(neoPolyWrapper, token)
+
+	// create an InvocationTransaction
+	itx, _, err := this.MakeInvocationTx(script, fromUint160)
+	if err != nil {
+		return "", fmt.Errorf("[ExtractFee] MakeInvocationTx error: %w", err)
+	}
+	// sign transaction
+	err = signer.SignNeoTx(itx, this.Signer)
+	if err != nil {
+		return "", fmt.Errorf("[ExtractFee] SignNeoTx error: %s", err)
+	}
+
+	rawTxString := itx.RawTransactionString()
+
+	// send the raw transaction
+	if err = this.sendRawTransaction(rawTxString); err != nil {
+		return "", fmt.Errorf("[ExtractFee] SendRawTransaction error: %s,  RawTransactionString: %s",
+			err, rawTxString)
+	}
+	log.Infof("Neo ExtractFee, txHash: %s", itx.HashString())
+	if _, err = this.waitTx(itx.HashString()); err != nil {
+		return "", fmt.Errorf("[ExtractFee] waitTx error: %w", err)
 	}
+
+	return itx.HashString(), nil
+}
+
+// LockScript builds the script sent by Lock, from is the little endian script hash of the sender
+func LockScript(neoPolyWrapper, fromAssetHash, from []byte, toChainId uint64, toAddress []byte, amount, fee, id *big.Int) []byte {
 	fromAssetHashValue := sc.ContractParameter{
 		Type:  sc.ByteArray,
 		Value: fromAssetHash,
@@ -126,88 +224,45 @@ func (this *NeoInvoker) Lock(neoPolyWrapper []byte, fromAssetHash []byte, toChai
 		Type:  sc.Integer,
 		Value: *id,
 	}
-	// build script
 	scriptBuilder := sc.NewScriptBuilder()
 	args := []sc.ContractParameter{fromAssetHashValue, fromAddressValue, toChainIdValue, toAddressValue, amountValue, feeValue, idValue}
 	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "lock", args)
-	script := scriptBuilder.ToArray()
-
-	// create an InvocationTransaction
-	tb := tx.NewTransactionBuilder(this.Cli.Endpoint.String())
-
-	sysFee := helper.Fixed8FromFloat64(0)
-	netFee := helper.Fixed8FromFloat64(0)
+	return scriptBuilder.ToArray()
+}
 
-	itx, err := tb.MakeInvocationTransaction(script, fromUint160, nil, fromUint160, sysFee, netFee)
-	if err != nil {
-		return "", fmt.Errorf("[LockFromWrapper] tb.MakeInvocationTransaction error: %s", err)
+// SpeedUpScript builds the script sent by SpeedUp, lockTxHash is little endian, the same as the
+// tx hash seen by the contract
+func SpeedUpScript(neoPolyWrapper, fromAssetHash, from, lockTxHash []byte, fee *big.Int) []byte {
+	fromAssetHashValue := sc.ContractParameter{
+		Type:  sc.ByteArray,
+		Value: fromAssetHash,
 	}
-	// sign transaction
-	err = tx.AddSignature(itx, this.Acc.KeyPair)
-	if err != nil {
-		return "", fmt.Errorf("[LockFromWrapper] tx.AddSignature error: %s", err)
+	fromAddressValue := sc.ContractParameter{
+		Type:  sc.ByteArray,
+		Value: from,
 	}
-
-	rawTxString := itx.RawTransactionString()
-
-	// send the raw transaction
-	response := this.Cli.SendRawTransaction(rawTxString)
-	if response.HasError() {
-		return "", fmt.Errorf("[LockFromWrapper] SendRawTransaction error: %s,  RawTransactionString: %s",
-			response.ErrorResponse.Error.Message, rawTxString)
+	txHashValue := sc.ContractParameter{
+		Type:  sc.ByteArray,
+		Value: lockTxHash,
 	}
-	log.Infof("Neo LockFromWrapper, txHash: %s", itx.HashString())
-	WaitNeoTx(this.Cli, itx.Hash)
-
-	return itx.HashString(), nil
+	feeValue := sc.ContractParameter{
+		Type:  sc.Integer,
+		Value: *fee,
+	}
+	scriptBuilder := sc.NewScriptBuilder()
+	args := []sc.ContractParameter{fromAssetHashValue, fromAddressValue, txHashValue, feeValue}
+	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "speedUp", args)
+	return scriptBuilder.ToArray()
 }
 
-func (this *NeoInvoker) ExtractFee(neoPolyWrapper []byte, token []byte) (string, error) {
-	from, err := ParseNeoAddr(this.Acc.Address)
-	if err != nil {
-		return "", fmt.Errorf("[ExtractFee], ParseNeoAddr acct: %s,  err: %v", this.Acc.Address, err)
-	}
-	fromUint160, err := helper.UInt160FromBytes(from)
-	if err != nil {
-		return "", fmt.Errorf("[ExtractFee], Uint160FromBytes err: %v", err)
-	}
+// ExtractFeeScript builds the script sent by ExtractFee
+func ExtractFeeScript(neoPolyWrapper []byte, token []byte) []byte {
 	fromAssetHashValue := sc.ContractParameter{
 		Type:  sc.ByteArray,
 		Value: token,
 	}
-
-	// build script
 	scriptBuilder := sc.NewScriptBuilder()
 	args := []sc.ContractParameter{fromAssetHashValue}
 	scriptBuilder.MakeInvocationScript(neoPolyWrapper, "extractFee", args)
-	script := scriptBuilder.ToArray()
-
-	// create an InvocationTransaction
-	tb := tx.NewTransactionBuilder(this.Cli.Endpoint.String())
-
-	sysFee := helper.Fixed8FromFloat64(0)
-	netFee := helper.Fixed8FromFloat64(0)
-
-	itx, err := tb.MakeInvocationTransaction(script, fromUint160, nil, fromUint160, sysFee, netFee)
-	if err != nil {
-		return "", fmt.Errorf("[ExtractFee] tb.MakeInvocationTransaction error: %s", err)
-	}
-	// sign transaction
-	err = tx.AddSignature(itx, this.Acc.KeyPair)
-	if err != nil {
-		return "", fmt.Errorf("[ExtractFee] tx.AddSignature error: %s", err)
-	}
-
-	rawTxString := itx.RawTransactionString()
-
-	// send the raw transaction
-	response := this.Cli.SendRawTransaction(rawTxString)
-	if response.HasError() {
-		return "", fmt.Errorf("[ExtractFee] SendRawTransaction error: %s,  RawTransactionString: %s",
-			response.ErrorResponse.Error.Message, rawTxString)
-	}
-	log.Infof("Neo ExtractFee, txHash: %s", itx.HashString())
-	WaitNeoTx(this.Cli, itx.Hash)
-
-	return itx.HashString(), nil
+	return scriptBuilder.ToArray()
 }
@@ -1,148 +1,173 @@
 package neo
 
 import (
+	"bytes"
 	"encoding/hex"
-	"fmt"
-	"github.com/skyinglyh1/poly_wrapper/config"
-	"github.com/skyinglyh1/poly_wrapper/log"
-	"io/ioutil"
+	"errors"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/polynetwork/poly/common"
 	"math/big"
-	"os"
 	"testing"
 )
 
-func ReadFile(fileName string) ([]byte, error) {
-	file, err := os.OpenFile(fileName, os.O_RDONLY, 0666)
-	if err != nil {
-		return nil, fmt.Errorf("OpenFile %s error %s", fileName, err)
-	}
-	defer func() {
-		err := file.Close()
-		if err != nil {
-			log.Errorf("File %s close error %s", fileName, err)
-		}
-	}()
-	data, err := ioutil.ReadAll(file)
+var (
+	testNNeo     = "0x17da3881ab2d050fea414c80b3fa8324d756f60e"
+	testLockHash = "0xedd2862dceb90b945210372d229f453f2b705f4f"
+)
 
-	if err != nil {
-		return nil, fmt.Errorf("ioutil.ReadAll %s error %s", fileName, err)
-	}
-	return data, nil
+func hashItem(h []byte) map[string]interface{} {
+	return map[string]interface{}{"type": "ByteArray", "value": hex.EncodeToString(h)}
 }
 
-func Test_DeployNeo_Wrapper(t *testing.T) {
-	config.DefConfig.Init("./config.json")
-	invoker, err := NewNeoInvoker(config.DefConfig.NeoUrl, config.DefConfig.NeoWallet, config.DefConfig.NeoWalletPwd)
-	if err != nil {
-		t.Fatal(err)
-	}
-	code, err := ReadFile("TODO")
-	if err != nil {
-		log.Errorf("ReadFile err: %v", err)
-		return
-	}
-	invoker.Deploy(code)
+func intItem(i int64) map[string]interface{} {
+	return map[string]interface{}{"type": "Integer", "value": big.NewInt(i).String()}
+}
+
+func boolItem(b bool) map[string]interface{} {
+	return map[string]interface{}{"type": "Boolean", "value": b}
+}
+
+func argInt(item ScriptItem) int64 {
+	return helper.BigIntFromNeoBytes(item.Bytes).Int64()
 }
 
 func Test_Get_Poly_Neo_Wrapper(t *testing.T) {
-	config.DefConfig.Init("./config.json")
-	invoker, err := NewNeoInvoker(config.DefConfig.NeoUrl, config.DefConfig.NeoWallet, config.DefConfig.NeoWalletPwd)
-	if err != nil {
-		t.Fatal(err)
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	polyNeoWrapper, _ := ParseNeoAddr(testWrapper)
+	neoLock, _ := ParseNeoAddr(testLockHash)
+	owner, _ := ParseNeoAddr(acc.Address)
+	fake.Reads["lockProxy"] = hashItem(neoLock)
+	fake.Reads["owner"] = hashItem(owner)
+	fake.Reads["feeCollector"] = hashItem(polyNeoWrapper)
+	fake.Reads["balanceOf"] = intItem(1200)
+
+	lockProxy, err := invoker.LockProxy(polyNeoWrapper)
+	if err != nil || lockProxy.String() != testLockHash {
+		t.Fatalf("LockProxy: %s, err: %v", lockProxy, err)
+	}
+	res, err := invoker.Owner(polyNeoWrapper)
+	if err != nil || res.Address() != acc.Address {
+		t.Fatalf("Owner: %s, err: %v", res.Address(), err)
 	}
-	//ccmc, _ := ParseNeoAddr("0xe1695b1314a1331e3935481620417ed835669407")
-	//neoLock, _ := ParseNeoAddr("0xedd2862dceb90b945210372d229f453f2b705f4f")
-	polyNeoWrapper, _ := ParseNeoAddr("0xcd074cd290acc3d73c030784101afbcf40fd86a1")
-
-	// check lockproxy
-	{
-		res, err := invoker.LockProxy(polyNeoWrapper)
-		if err != nil {
-			log.Debugf("LockProxy err: %v", err)
-			return
-		}
-		log.Debugf("neo poly wrapper is: %s", res)
-	}
-
-	{
-		res, err := invoker.Owner(polyNeoWrapper)
-		if err != nil {
-			log.Errorf("Owner err: %v", err)
-			return
-		}
-		log.Infof("neo poly wrapper Owner: %s", res)
-	}
-
-	{
-		res, err := invoker.FeeCollector(polyNeoWrapper)
-		if err != nil {
-			log.Errorf("FeeCollector err: %v", err)
-			return
-		}
-		log.Infof("neo poly wrapper FeeCollector: %s", res)
-	}
-	fromAssetHashs := []string{
-		"0x843e9f7a4ba7e062a53d7bbbe85cb35421704616",
-		"0xbb01ac51a4c49bd28676274726497ab27ae8f66c",
-		"0x17da3881ab2d050fea414c80b3fa8324d756f60e",
-	}
-	{
-		froms := make([][]byte, 0)
-		for _, from := range fromAssetHashs {
-			f, _ := ParseNeoAddr(from)
-			froms = append(froms, f)
-		}
-		bals, err := invoker.GetAssetBalances(polyNeoWrapper, froms)
-		if err != nil {
-			log.Errorf("GetAssetBalances err: %v", err)
-			return
-		}
-
-		for i, _ := range fromAssetHashs {
-			log.Infof("poly neo wrapper GetAssetHashs, fromAssetHash: %s, wrapperBalance: %s", fromAssetHashs[i], bals[i].String())
-		}
+	res, err = invoker.FeeCollector(polyNeoWrapper)
+	if err != nil || res.String() != testWrapper {
+		t.Fatalf("FeeCollector: %s, err: %v", res, err)
+	}
+	froms := make([][]byte, 0)
+	for _, from := range []string{"0x843e9f7a4ba7e062a53d7bbbe85cb35421704616", testNNeo} {
+		f, _ := ParseNeoAddr(from)
+		froms = append(froms, f)
+	}
+	bals, err := invoker.GetAssetBalances(polyNeoWrapper, froms)
+	if err != nil || len(bals) != 2 || bals[0].Int64() != 1200 || bals[1].Int64() != 1200 {
+		t.Fatalf("GetAssetBalances: %v, err: %v", bals, err)
+	}
+	// the balances are read in one script, of the wrapper
+	calls, _ := DecodeInvocationScript(fake.Invoked[len(fake.Invoked)-1])
+	if len(calls) != 2 || hex.EncodeToString(calls[1].ScriptHash) != hex.EncodeToString(froms[1]) || hex.EncodeToString(calls[1].Args[0].Bytes) != hex.EncodeToString(polyNeoWrapper) {
+		t.Fatalf("unexpected balance script: %+v", calls)
 	}
 }
 
 func Test_Lock_PolyWrapper(t *testing.T) {
-	config.DefConfig.Init("./config.json")
-	invoker, err := NewNeoInvoker(config.DefConfig.NeoUrl, config.DefConfig.NeoWallet, config.DefConfig.NeoWalletPwd)
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	polyNeoWrapper, _ := ParseNeoAddr(testWrapper)
+	fromAsset, _ := ParseNeoAddr(testNNeo)
+	toAddr, _ := hex.DecodeString("352631d51332f8e6657ae94329d268eb68ca26f7")
+	fake.Reads["lock"] = boolItem(true)
+
+	txHash, err := invoker.Lock(polyNeoWrapper, fromAsset, 79, toAddr, big.NewInt(2), big.NewInt(1), big.NewInt(0))
 	if err != nil {
 		t.Fatal(err)
 	}
-	//ccmc, _ := ParseNeoAddr("0xe1695b1314a1331e3935481620417ed835669407")
-	//neoLock, _ := ParseNeoAddr("0xedd2862dceb90b945210372d229f453f2b705f4f")
-	polyNeoWrapper, _ := ParseNeoAddr("0xcd074cd290acc3d73c030784101afbcf40fd86a1")
+	if len(fake.Sent) != 1 || !sameTxHash(fake.Sent[0].HashString(), txHash) {
+		t.Fatalf("lock tx %s not sent", txHash)
+	}
+	from, _ := ParseNeoAddr(acc.Address)
+	calls := fake.sentCalls(0)
+	args := calls[0].Args
+	if len(calls) != 1 || calls[0].Method != "lock" || len(args) != 7 {
+		t.Fatalf("unexpected lock calls: %+v", calls)
+	}
+	if hex.EncodeToString(args[0].Bytes) != hex.EncodeToString(fromAsset) || hex.EncodeToString(args[1].Bytes) != hex.EncodeToString(from) ||
+		argInt(args[2]) != 79 || hex.EncodeToString(args[3].Bytes) != hex.EncodeToString(toAddr) || argInt(args[4]) != 2 || argInt(args[5]) != 1 {
+		t.Fatalf("unexpected lock args: %+v", args)
+	}
 
-	fromAsset, _ := ParseNeoAddr("0x17da3881ab2d050fea414c80b3fa8324d756f60e") // nneo
-	//fromAsset, _ := ParseNeoAddr("0x23535b6fd46b8f867ed010bab4c2bd8ef0d0c64f") // pnweth
-	toAddr, _ := hex.DecodeString("352631d51332f8e6657ae94329d268eb68ca26f7")
+	// the contract rejects an amount not above the fee before anything is sent
+	fake.Faults["lock"] = FaultAmountLessThanFee
+	_, err = invoker.Lock(polyNeoWrapper, fromAsset, 79, toAddr, big.NewInt(1), big.NewInt(1), big.NewInt(0))
+	var faultErr *ContractFaultError
+	if !errors.As(err, &faultErr) || faultErr.Reason != FaultAmountLessThanFee || len(fake.Sent) != 1 {
+		t.Fatalf("expect the %s fault, got: %v", FaultAmountLessThanFee, err)
+	}
+}
 
-	txHash, err := invoker.Lock(polyNeoWrapper, fromAsset, 79, toAddr, big.NewInt(2), big.NewInt(1), big.NewInt(0))
+func Test_SpeedUp_PolyWrapper(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	polyNeoWrapper, _ := ParseNeoAddr(testWrapper)
+	fromAsset, _ := ParseNeoAddr(testNNeo)
+	lockTxHash, err := invoker.Lock(polyNeoWrapper, fromAsset, 79, common.ADDRESS_EMPTY[:], big.NewInt(2), big.NewInt(1), big.NewInt(0))
 	if err != nil {
-		log.Errorf("lock err: %v", err)
-		return
+		t.Fatal(err)
 	}
-	log.Infof("txHash: %s", txHash)
-}
 
-func Test_ExtractFee_PolyWrapper(t *testing.T) {
-	config.DefConfig.Init("./config.json")
-	invoker, err := NewNeoInvoker(config.DefConfig.NeoUrl, config.DefConfig.NeoWallet, config.DefConfig.NeoWalletPwd)
+	txHash, err := invoker.SpeedUp(polyNeoWrapper, fromAsset, lockTxHash, big.NewInt(1))
 	if err != nil {
 		t.Fatal(err)
 	}
-	//ccmc, _ := ParseNeoAddr("0xe1695b1314a1331e3935481620417ed835669407")
-	//neoLock, _ := ParseNeoAddr("0xedd2862dceb90b945210372d229f453f2b705f4f")
-	polyNeoWrapper, _ := ParseNeoAddr("0xcd074cd290acc3d73c030784101afbcf40fd86a1")
+	calls := fake.sentCalls(1)
+	if !sameTxHash(fake.Sent[1].HashString(), txHash) || calls[0].Method != "speedUp" || len(calls[0].Args) != 4 || argInt(calls[0].Args[3]) != 1 {
+		t.Fatalf("unexpected speedUp tx %s: %+v", txHash, calls)
+	}
+	// the contract sees the lock tx hash little endian
+	if hex.EncodeToString(calls[0].Args[2].Bytes) != hex.EncodeToString(fake.Sent[0].Hash.Bytes()) {
+		t.Fatalf("speedUp tx hash %x, lock tx %s", calls[0].Args[2].Bytes, lockTxHash)
+	}
+	// the simulation runs the script that was sent
+	sim, err := invoker.SimulateSpeedUp(polyNeoWrapper, fromAsset, lockTxHash, big.NewInt(1))
+	if err != nil || !sim.Halted() || sim.Method != "speedUp" {
+		t.Fatalf("unexpected speedUp simulation: %+v, err: %v", sim, err)
+	}
+	from, _ := ParseNeoAddr(acc.Address)
+	if script := SpeedUpScript(polyNeoWrapper, fromAsset, from, fake.Sent[0].Hash.Bytes(), big.NewInt(1)); !bytes.Equal(fake.Invoked[len(fake.Invoked)-1], script) {
+		t.Fatalf("simulated script %x, expect %x", fake.Invoked[len(fake.Invoked)-1], script)
+	}
+	if _, err = invoker.SpeedUp(polyNeoWrapper, fromAsset, testTxHash, big.NewInt(1)); err == nil {
+		t.Fatal("speeding up an unknown tx should fail")
+	}
+}
 
-	fromAsset, _ := ParseNeoAddr("0x17da3881ab2d050fea414c80b3fa8324d756f60e") // nneo
-	//fromAsset, _ := ParseNeoAddr("0x23535b6fd46b8f867ed010bab4c2bd8ef0d0c64f") // pnweth
+func Test_ExtractFee_PolyWrapper(t *testing.T) {
+	fake := newFakeNeoRpc(t)
+	acc, _ := wallet.NewAccount()
+	invoker := fake.invoker(acc)
+	polyNeoWrapper, _ := ParseNeoAddr(testWrapper)
+	fromAsset, _ := ParseNeoAddr(testNNeo)
 
 	txHash, err := invoker.ExtractFee(polyNeoWrapper, fromAsset)
 	if err != nil {
-		log.Errorf("extractFee err: %v", err)
-		return
+		t.Fatal(err)
+	}
+	calls := fake.sentCalls(0)
+	if !sameTxHash(fake.Sent[0].HashString(), txHash) || calls[0].Method != "extractFee" || hex.EncodeToString(calls[0].Args[0].Bytes) != hex.EncodeToString(fromAsset) {
+		t.Fatalf("unexpected extractFee tx %s: %+v", txHash, calls)
+	}
+
+	// the fee collector changes between invokescript and the block, the tx FAULTs on chain
+	fake.OnSent = func(calls []*ContractCall) {
+		fake.Faults["extractFee"] = FaultNotFeeCollector
+	}
+	_, err = invoker.ExtractFee(polyNeoWrapper, fromAsset)
+	var faultErr *ContractFaultError
+	if !errors.As(err, &faultErr) || faultErr.Reason != FaultNotFeeCollector || faultErr.TxHash == "" || len(fake.Sent) != 2 {
+		t.Fatalf("expect the %s fault, got: %v", FaultNotFeeCollector, err)
 	}
-	log.Infof("txHash: %s", txHash)
 }
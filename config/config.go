@@ -53,15 +53,64 @@ type BindAssetStruct struct {
 	ToAsset     string `json:"toAsset"`
 }
 
+// NeoContractMeta is the manifest of a NEO contract passed to Neo.Contract.Create
+type NeoContractMeta struct {
+	// hex of the parameter types and the return type of Main, e.g. "0710" and "05"
+	ParamTypes       string `json:"paramTypes"`
+	ReturnType       string `json:"returnType"`
+	HasStorage       bool   `json:"hasStorage"`
+	HasDynamicInvoke bool   `json:"hasDynamicInvoke"`
+	IsPayable        bool   `json:"isPayable"`
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	Author           string `json:"author"`
+	Email            string `json:"email"`
+	Description      string `json:"description"`
+}
+
 //Config object used by ontology-instance
 type TestConfig struct {
 	NeoChainID uint64 `json:"neoChainId,omitempty"`
 
 	// neo chain conf
-	NeoUrl       string `json:"neoUrl,omitempty"`
-	NeoWif       string `json:"neoWif,omitempty"`
-	NeoWallet    string `json:"neoWallet,omitempty"`
-	NeoWalletPwd string `json:"neoWalletPwd,omitempty"`
+	NeoUrl string `json:"neoUrl,omitempty"`
+	// rpc nodes to fail over between, in order of preference, NeoUrl is used if empty
+	NeoUrls      []string `json:"neoUrls,omitempty"`
+	NeoWif       string   `json:"neoWif,omitempty"`
+	NeoWallet    string   `json:"neoWallet,omitempty"`
+	NeoWalletPwd string   `json:"neoWalletPwd,omitempty"`
+	// address or label of the account in NeoWallet, the first account if empty
+	NeoAccount string `json:"neoAccount,omitempty"`
+	// remote signer holding NeoAccount, which must then be an address, see signer.RemoteNeoSigner
+	NeoSignerUrl string `json:"neoSignerUrl,omitempty"`
+	// "normal" or "high", see neo.FeePolicy
+	NeoFeePriority string  `json:"neoFeePriority,omitempty"`
+	NeoExtraNetFee float64 `json:"neoExtraNetFee,omitempty"`
+
+	// ethereum chain conf
+	EthUrl        string `json:"ethUrl,omitempty"`
+	EthPrivateKey string `json:"ethPrivateKey,omitempty"`
+	// remote signer holding EthAddress, used if EthPrivateKey is empty, see signer.RemoteEthSigner
+	EthSignerUrl string `json:"ethSignerUrl,omitempty"`
+	EthAddress   string `json:"ethAddress,omitempty"`
+
+	// poly wrapper deployment, see neo.DeployWrapper
+	NeoWrapperAvm   string           `json:"neoWrapperAvm,omitempty"`
+	NeoWrapperMeta  *NeoContractMeta `json:"neoWrapperMeta,omitempty"`
+	NeoLockProxy    string           `json:"neoLockProxy,omitempty"`
+	NeoFeeCollector string           `json:"neoFeeCollector,omitempty"`
+
+	// ethereum poly wrapper deployment, see eth.DeployWrapper. The lock proxy is read from the
+	// EthNetwork entry of EthDeployments, the deployed.json of truffle, and the result is written
+	// to EthDeploymentsOut, the development.json the truffle migration writes
+	EthWrapperArtifact string `json:"ethWrapperArtifact,omitempty"`
+	EthDeployments     string `json:"ethDeployments,omitempty"`
+	EthDeploymentsOut  string `json:"ethDeploymentsOut,omitempty"`
+	EthNetwork         string `json:"ethNetwork,omitempty"`
+	// poly chain id of the ethereum chain, 2 for ethereum, 6 for bsc and 7 for heco
+	EthPolyChainId uint64 `json:"ethPolyChainId,omitempty"`
+	// the signer collects the fees if empty
+	EthFeeCollector string `json:"ethFeeCollector,omitempty"`
 
 	ProxyToBind []BindProxyStruct `json:"proxyToBind,omitempty"`
 	AssetToBind []BindAssetStruct `json:"assetToBind,omitempty"`
@@ -95,6 +144,14 @@ func (conf *TestConfig) Init(fileName string) error {
 	return nil
 }
 
+// NeoRpcUrls returns NeoUrls, or NeoUrl alone if NeoUrls is empty
+func (conf *TestConfig) NeoRpcUrls() []string {
+	if len(conf.NeoUrls) > 0 {
+		return conf.NeoUrls
+	}
+	return []string{conf.NeoUrl}
+}
+
 /**
 Load JSON Configuration
 */
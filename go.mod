@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/btcsuite/btcd v0.21.0-beta
+	github.com/ethereum/go-ethereum v1.9.15
 	github.com/joeqian10/neo-gogogo v0.0.0-20210120033000-0b38545f3328
 	github.com/ontio/ontology v1.11.1-0.20200812075204-26cf1fa5dd47
 	github.com/polynetwork/poly v0.0.0-20200715030435-4f1d1a0adb44
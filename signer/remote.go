@@ -0,0 +1,192 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/joeqian10/neo-gogogo/wallet/keys"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRemoteTimeout bounds one call to a remote signer, which may wait for a human approval
+const DefaultRemoteTimeout = 2 * time.Minute
+
+// RemoteClient calls a JSON-RPC 2.0 signer over HTTP
+type RemoteClient struct {
+	Url  string
+	Http *http.Client
+	id   uint64
+}
+
+func NewRemoteClient(url string) *RemoteClient {
+	return &RemoteClient{Url: url, Http: &http.Client{Timeout: DefaultRemoteTimeout}}
+}
+
+type rpcRequest struct {
+	JsonRpc string        `json:"jsonrpc"`
+	Id      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Call sends method with params and decodes the result into result
+func (this *RemoteClient) Call(result interface{}, method string, params ...interface{}) error {
+	body, err := json.Marshal(&rpcRequest{
+		JsonRpc: "2.0",
+		Id:      atomic.AddUint64(&this.id, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("%s, marshal request err: %v", method, err)
+	}
+	resp, err := this.Http.Post(this.Url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s, post to %s err: %v", method, this.Url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s, %s replied %s", method, this.Url, resp.Status)
+	}
+	res := &rpcResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("%s, decode response err: %v", method, err)
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%s, remote signer error %d: %s", method, res.Error.Code, res.Error.Message)
+	}
+	if err = json.Unmarshal(res.Result, result); err != nil {
+		return fmt.Errorf("%s, decode result err: %v", method, err)
+	}
+	return nil
+}
+
+// RemoteNeoSigner asks a remote signer to sign for address. The signer serves
+// neo_publicKey(address) returning the compressed public key in hex and
+// neo_sign(address, msg) returning the 64 bytes signature of msg in hex
+type RemoteNeoSigner struct {
+	Client    *RemoteClient
+	address   string
+	publicKey *keys.PublicKey
+}
+
+// NewRemoteNeoSigner fetches the public key of address and checks it matches
+func NewRemoteNeoSigner(url, address string) (*RemoteNeoSigner, error) {
+	client := NewRemoteClient(url)
+	var pubHex string
+	if err := client.Call(&pubHex, "neo_publicKey", address); err != nil {
+		return nil, fmt.Errorf("NewRemoteNeoSigner, %v", err)
+	}
+	pub, err := keys.NewPublicKeyFromString(pubHex)
+	if err != nil {
+		return nil, fmt.Errorf("NewRemoteNeoSigner, public key %s err: %v", pubHex, err)
+	}
+	if pub.Address() != address {
+		return nil, fmt.Errorf("NewRemoteNeoSigner, public key %s is of %s, not %s", pubHex, pub.Address(), address)
+	}
+	return &RemoteNeoSigner{Client: client, address: address, publicKey: pub}, nil
+}
+
+func (this *RemoteNeoSigner) Address() string {
+	return this.address
+}
+
+func (this *RemoteNeoSigner) PublicKey() *keys.PublicKey {
+	return this.publicKey
+}
+
+// SignNeo checks the signature returned, so a misbehaving signer fails here and not on chain
+func (this *RemoteNeoSigner) SignNeo(msg []byte) ([]byte, error) {
+	var sigHex string
+	if err := this.Client.Call(&sigHex, "neo_sign", this.address, hex.EncodeToString(msg)); err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil || len(sig) != 64 || !keys.VerifySignature(msg, sig, this.publicKey) {
+		return nil, fmt.Errorf("neo_sign, invalid signature %s from %s", sigHex, this.Client.Url)
+	}
+	return sig, nil
+}
+
+// RemoteEthSigner signs with account_signTransaction of clef, the chain id is configured on the
+// clef side and checked here against the one given to SignEthTx
+type RemoteEthSigner struct {
+	Client  *RemoteClient
+	address common.Address
+}
+
+func NewRemoteEthSigner(url string, address common.Address) *RemoteEthSigner {
+	return &RemoteEthSigner{Client: NewRemoteClient(url), address: address}
+}
+
+// ethSendTxArgs is the SendTxArgs of clef
+type ethSendTxArgs struct {
+	From     common.MixedcaseAddress  `json:"from"`
+	To       *common.MixedcaseAddress `json:"to"`
+	Gas      hexutil.Uint64           `json:"gas"`
+	GasPrice hexutil.Big              `json:"gasPrice"`
+	Value    hexutil.Big              `json:"value"`
+	Nonce    hexutil.Uint64           `json:"nonce"`
+	Data     *hexutil.Bytes           `json:"data"`
+}
+
+type ethSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+func (this *RemoteEthSigner) EthAddress() common.Address {
+	return this.address
+}
+
+// SignEthTx checks that the signed tx returned is the one asked for, signed by this account
+func (this *RemoteEthSigner) SignEthTx(tx *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := &ethSendTxArgs{
+		From:     common.NewMixedcaseAddress(this.address),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: hexutil.Big(*tx.GasPrice()),
+		Value:    hexutil.Big(*tx.Value()),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Data:     &data,
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	res := &ethSignTxResult{}
+	if err := this.Client.Call(res, "account_signTransaction", args); err != nil {
+		return nil, err
+	}
+	signed := new(types.Transaction)
+	if err := rlp.DecodeBytes(res.Raw, signed); err != nil {
+		return nil, fmt.Errorf("account_signTransaction, decode raw tx err: %v", err)
+	}
+	ethSigner := types.NewEIP155Signer(chainId)
+	sender, err := types.Sender(ethSigner, signed)
+	if err != nil {
+		return nil, fmt.Errorf("account_signTransaction, signature for chain %s err: %v", chainId.String(), err)
+	}
+	if sender != this.address {
+		return nil, fmt.Errorf("account_signTransaction, signed by %s, not %s", sender.Hex(), this.address.Hex())
+	}
+	if ethSigner.Hash(signed) != ethSigner.Hash(tx) {
+		return nil, fmt.Errorf("account_signTransaction, signed tx %s differs from the one asked", signed.Hash().Hex())
+	}
+	return signed, nil
+}
@@ -0,0 +1,178 @@
+// Package signer abstracts the keys signing NEO and Ethereum transactions, so the tooling can
+// run with a local key, a NEP-6 wallet or a remote signer holding the keys in another process
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joeqian10/neo-gogogo/helper"
+	"github.com/joeqian10/neo-gogogo/sc"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"github.com/joeqian10/neo-gogogo/wallet/keys"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// NeoSigner signs NEO transactions for one single signature account
+type NeoSigner interface {
+	// Address is the base58 address of the account
+	Address() string
+	PublicKey() *keys.PublicKey
+	// SignNeo returns the 64 bytes secp256r1 signature of msg, the unsigned raw tx
+	SignNeo(msg []byte) ([]byte, error)
+}
+
+// EthSigner signs Ethereum transactions for one account
+type EthSigner interface {
+	EthAddress() common.Address
+	SignEthTx(tx *types.Transaction, chainId *big.Int) (*types.Transaction, error)
+}
+
+// NeoKeySigner signs with a NEO key pair held in this process
+type NeoKeySigner struct {
+	KeyPair *keys.KeyPair
+}
+
+func NewNeoKeySigner(keyPair *keys.KeyPair) *NeoKeySigner {
+	return &NeoKeySigner{KeyPair: keyPair}
+}
+
+func NewNeoWIFSigner(wif string) (*NeoKeySigner, error) {
+	keyPair, err := keys.NewKeyPairFromWIF(wif)
+	if err != nil {
+		return nil, fmt.Errorf("NewNeoWIFSigner, NewKeyPairFromWIF err: %v", err)
+	}
+	return NewNeoKeySigner(keyPair), nil
+}
+
+// NewNep6Signer signs with the account of a NEP-6 wallet, see LoadNep6Account
+func NewNep6Signer(walletPath, pwd, account string) (*NeoKeySigner, error) {
+	acc, err := LoadNep6Account(walletPath, pwd, account)
+	if err != nil {
+		return nil, err
+	}
+	return NewNeoKeySigner(acc.KeyPair), nil
+}
+
+func (this *NeoKeySigner) Address() string {
+	return this.KeyPair.PublicKey.Address()
+}
+
+func (this *NeoKeySigner) PublicKey() *keys.PublicKey {
+	return this.KeyPair.PublicKey
+}
+
+func (this *NeoKeySigner) SignNeo(msg []byte) ([]byte, error) {
+	return this.KeyPair.Sign(msg)
+}
+
+// LoadNep6Account returns the account of a NEP-6 wallet whose address or label is account, or
+// the first one if account is empty. Only that account is decrypted, the others may use other
+// passwords
+func LoadNep6Account(walletPath, pwd, account string) (*wallet.Account, error) {
+	w, err := wallet.NewWalletFromFile(walletPath)
+	if err != nil {
+		return nil, fmt.Errorf("[LoadNep6Account] open NEO wallet %s err: %v", walletPath, err)
+	}
+	if pwd == "" {
+		return nil, fmt.Errorf("[LoadNep6Account] pls provide neo wallet pwd")
+	}
+	if len(w.Accounts) == 0 {
+		return nil, fmt.Errorf("[LoadNep6Account] empty wallet %s", walletPath)
+	}
+	acc := w.Accounts[0]
+	if account != "" {
+		acc = nil
+		known := make([]string, 0, len(w.Accounts))
+		for _, a := range w.Accounts {
+			if a.Address == account || (a.Label != "" && a.Label == account) {
+				acc = a
+				break
+			}
+			known = append(known, fmt.Sprintf("%s(%s)", a.Address, a.Label))
+		}
+		if acc == nil {
+			return nil, fmt.Errorf("[LoadNep6Account] no account %s in wallet %s, accounts: %s", account, walletPath, strings.Join(known, ", "))
+		}
+	}
+	if acc.Nep2Key == "" && acc.KeyPair == nil {
+		return nil, fmt.Errorf("[LoadNep6Account] account %s is watch only", acc.Address)
+	}
+	if err = acc.Decrypt(pwd); err != nil {
+		return nil, fmt.Errorf("[LoadNep6Account] decrypt NEO account %s err: %v", acc.Address, err)
+	}
+	return acc, nil
+}
+
+// EthKeySigner signs with an Ethereum private key held in this process
+type EthKeySigner struct {
+	Key *ecdsa.PrivateKey
+}
+
+// NewEthKeySigner takes the private key in hex, with or without 0x
+func NewEthKeySigner(hexKey string) (*EthKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("NewEthKeySigner, HexToECDSA err: %v", err)
+	}
+	return &EthKeySigner{Key: key}, nil
+}
+
+func (this *EthKeySigner) EthAddress() common.Address {
+	return crypto.PubkeyToAddress(this.Key.PublicKey)
+}
+
+func (this *EthKeySigner) SignEthTx(tx *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.NewEIP155Signer(chainId), this.Key)
+}
+
+// SignNeoTx adds the witness of s to transaction like tx.AddSignature does for a key pair, the
+// script hash of s becomes a script attribute if the tx has no witness yet
+func SignNeoTx(transaction tx.ITransaction, s NeoSigner) error {
+	verificationScript := keys.CreateSignatureRedeemScript(s.PublicKey())
+	scriptHash, err := helper.BytesToScriptHash(verificationScript)
+	if err != nil {
+		return fmt.Errorf("SignNeoTx, BytesToScriptHash err: %v", err)
+	}
+	t := transaction.GetTransaction()
+	for _, witness := range t.Witnesses {
+		if witness.GetScriptHash() == scriptHash {
+			return nil
+		}
+	}
+	if len(t.Witnesses) == 0 {
+		t.AddScriptHashToAttribute(scriptHash)
+	}
+	sig, err := s.SignNeo(transaction.UnsignedRawTransaction())
+	if err != nil {
+		return fmt.Errorf("SignNeoTx, sign by %s err: %v", s.Address(), err)
+	}
+	sb := sc.NewScriptBuilder()
+	sb.EmitPushBytes(sig)
+	witness, err := tx.CreateWitness(sb.ToArray(), verificationScript)
+	if err != nil {
+		return fmt.Errorf("SignNeoTx, CreateWitness err: %v", err)
+	}
+	t.Witnesses = append(t.Witnesses, witness)
+	sort.Sort(tx.WitnessSlice(t.Witnesses))
+	return nil
+}
+
+// NewEthTransactOpts lets the abigen bindings send their txs signed by s
+func NewEthTransactOpts(s EthSigner, chainId *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: s.EthAddress(),
+		Signer: func(_ types.Signer, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != s.EthAddress() {
+				return nil, fmt.Errorf("NewEthTransactOpts, %s can not sign for %s", s.EthAddress().Hex(), addr.Hex())
+			}
+			return s.SignEthTx(tx, chainId)
+		},
+	}
+}
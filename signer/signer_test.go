@@ -0,0 +1,144 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/joeqian10/neo-gogogo/tx"
+	"github.com/joeqian10/neo-gogogo/wallet"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// standInSigner serves the remote signer methods with local keys, tamper changes its replies
+type standInSigner struct {
+	neo    *wallet.Account
+	eth    *EthKeySigner
+	chain  *big.Int
+	tamper bool
+}
+
+func (this *standInSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &struct {
+		Id     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var result interface{}
+	var rpcErr interface{}
+	switch req.Method {
+	case "neo_publicKey":
+		result = hex.EncodeToString(this.neo.KeyPair.PublicKey.EncodeCompression())
+	case "neo_sign":
+		var msgHex string
+		json.Unmarshal(req.Params[1], &msgHex)
+		msg, _ := hex.DecodeString(msgHex)
+		if this.tamper {
+			msg = append(msg, 0)
+		}
+		sig, _ := this.neo.KeyPair.Sign(msg)
+		result = hex.EncodeToString(sig)
+	case "account_signTransaction":
+		args := &ethSendTxArgs{}
+		json.Unmarshal(req.Params[0], args)
+		if args.From.Address() != this.eth.EthAddress() {
+			rpcErr = map[string]interface{}{"code": -32000, "message": "unknown account"}
+			break
+		}
+		nonce := uint64(args.Nonce)
+		if this.tamper {
+			nonce++
+		}
+		unsigned := types.NewTransaction(nonce, args.To.Address(), args.Value.ToInt(), uint64(args.Gas), args.GasPrice.ToInt(), *args.Data)
+		signed, _ := this.eth.SignEthTx(unsigned, this.chain)
+		raw, _ := rlp.EncodeToBytes(signed)
+		result = map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}
+	default:
+		rpcErr = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.Id, "result": result, "error": rpcErr})
+}
+
+func Test_RemoteNeoSigner(t *testing.T) {
+	acc, _ := wallet.NewAccount()
+	other, _ := wallet.NewAccount()
+	stand := &standInSigner{neo: acc}
+	srv := httptest.NewServer(stand)
+	defer srv.Close()
+
+	if _, err := NewRemoteNeoSigner(srv.URL, other.Address); err == nil {
+		t.Fatal("a public key of another address should be rejected")
+	}
+	s, err := NewRemoteNeoSigner(srv.URL, acc.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	itx := tx.NewInvocationTransaction([]byte{0x61})
+	if err = SignNeoTx(itx, s); err != nil {
+		t.Fatal(err)
+	}
+	if len(itx.Witnesses) != 1 || !tx.VerifySignatureWitness(itx.UnsignedRawTransaction(), itx.Witnesses[0]) {
+		t.Fatalf("unexpected witnesses: %d", len(itx.Witnesses))
+	}
+	// the remote witness is the one of the local key
+	local := tx.NewInvocationTransaction([]byte{0x61})
+	if err = SignNeoTx(local, NewNeoKeySigner(acc.KeyPair)); err != nil {
+		t.Fatal(err)
+	}
+	if local.Witnesses[0].GetScriptHash() != itx.Witnesses[0].GetScriptHash() {
+		t.Fatalf("remote witness of %s, local witness of %s", itx.Witnesses[0].GetScriptHash().String(), local.Witnesses[0].GetScriptHash().String())
+	}
+
+	stand.tamper = true
+	if _, err = s.SignNeo([]byte{1, 2, 3}); err == nil {
+		t.Fatal("a signature of another message should be rejected")
+	}
+}
+
+func Test_RemoteEthSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	local := &EthKeySigner{Key: key}
+	stand := &standInSigner{eth: local, chain: big.NewInt(1)}
+	srv := httptest.NewServer(stand)
+	defer srv.Close()
+
+	to := common.HexToAddress("0xde1ea5c87b4b1d4f0fec0d5e8bd7ee0fc4c5cd25")
+	unsigned := types.NewTransaction(7, to, big.NewInt(100), 21000, big.NewInt(10), []byte{0xab})
+	s := NewRemoteEthSigner(srv.URL, local.EthAddress())
+	signed, err := s.SignEthTx(unsigned, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(big.NewInt(1)), signed)
+	if err != nil || sender != local.EthAddress() || signed.Nonce() != 7 {
+		t.Fatalf("unexpected signed tx from %s, err: %v", sender.Hex(), err)
+	}
+	if _, err = s.SignEthTx(unsigned, big.NewInt(2)); err == nil {
+		t.Fatal("a signature for another chain should be rejected")
+	}
+	if _, err = NewRemoteEthSigner(srv.URL, to).SignEthTx(unsigned, big.NewInt(1)); err == nil {
+		t.Fatal("an account unknown to the signer should fail")
+	}
+	stand.tamper = true
+	if _, err = s.SignEthTx(unsigned, big.NewInt(1)); err == nil {
+		t.Fatal("a signed tx differing from the one asked should be rejected")
+	}
+
+	opts := NewEthTransactOpts(s, big.NewInt(1))
+	if opts.From != local.EthAddress() {
+		t.Fatalf("unexpected transact opts from %s", opts.From.Hex())
+	}
+	if _, err = opts.Signer(nil, to, unsigned); err == nil {
+		t.Fatal("transact opts should not sign for another address")
+	}
+}